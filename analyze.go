@@ -0,0 +1,388 @@
+package forge
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Complexity estimates how expensive an HTML document is to render.
+type Complexity struct {
+	// Bytes is the document size.
+	Bytes int
+	// Elements is the number of elements.
+	Elements int
+	// MaxDepth is the deepest element nesting.
+	MaxDepth int
+	// ExternalResources counts absolute http(s) URLs the browser would
+	// fetch: images, stylesheets, scripts, frames, and CSS url() references.
+	ExternalResources int
+	// Scripts is the number of script elements.
+	Scripts int
+	// Risky lists constructs that commonly cause slow or failed renders,
+	// such as frames, plugins, meta refreshes, and javascript: URLs, once
+	// each in order of appearance.
+	Risky []string
+}
+
+// ComplexityLimits are the thresholds enforced by WithComplexityLimits.
+// Zero fields are not checked.
+type ComplexityLimits struct {
+	MaxBytes             int
+	MaxElements          int
+	MaxDepth             int
+	MaxExternalResources int
+	MaxScripts           int
+	// RejectRisky rejects documents with any Complexity.Risky construct.
+	RejectRisky bool
+}
+
+// WithComplexityLimits rejects RenderHTML requests whose document exceeds
+// the limits, as reported by AnalyzeHTML, with a *ValidationError before
+// anything is sent. It protects the cluster from pathological
+// user-generated documents.
+func WithComplexityLimits(limits ComplexityLimits) Option {
+	return func(c *Client) {
+		c.limits = &limits
+	}
+}
+
+// voidElements never have content or an end tag.
+var voidElements = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true, "hr": true, "img": true,
+	"input": true, "link": true, "meta": true, "source": true, "track": true, "wbr": true,
+}
+
+// riskyElements are elements that load nested documents or plugins.
+var riskyElements = map[string]bool{"iframe": true, "frame": true, "object": true, "embed": true, "applet": true}
+
+// resourceAttrs are attributes whose URLs the browser fetches.
+var resourceAttrs = map[string]bool{"src": true, "srcset": true, "poster": true, "data": true, "background": true}
+
+// AnalyzeHTML scans an HTML document and estimates its rendering cost
+// without rendering it. The scan is lenient like a browser; it returns an
+// error only for input a browser would truncate, such as an unterminated
+// comment or tag.
+func AnalyzeHTML(html string) (Complexity, error) {
+	a := newAnalyzer()
+	a.c.Bytes = len(html)
+	err := a.scan(html)
+	return a.c, err
+}
+
+type analyzer struct {
+	c     Complexity
+	stack []string
+	// open counts the elements of each name on stack, so that unmatched
+	// end tags are skipped without searching it.
+	open map[string]int
+	seen map[string]bool
+	// refs lists every resource URL in the document, in order.
+	refs []string
+}
+
+func newAnalyzer() *analyzer {
+	return &analyzer{seen: map[string]bool{}, open: map[string]int{}}
+}
+
+func (a *analyzer) risky(what string) {
+	if !a.seen[what] {
+		a.seen[what] = true
+		a.c.Risky = append(a.c.Risky, what)
+	}
+}
+
+func (a *analyzer) scan(s string) error {
+	for {
+		i := strings.IndexByte(s, '<')
+		if i < 0 {
+			return nil
+		}
+		s = s[i:]
+		switch {
+		case strings.HasPrefix(s, "<!--"):
+			end := strings.Index(s[4:], "-->")
+			if end < 0 {
+				return errors.New("forge: unterminated HTML comment")
+			}
+			s = s[4+end+3:]
+		case strings.HasPrefix(s, "<!"), strings.HasPrefix(s, "<?"):
+			end := strings.IndexByte(s, '>')
+			if end < 0 {
+				return errors.New("forge: unterminated HTML declaration")
+			}
+			s = s[end+1:]
+		case strings.HasPrefix(s, "</"):
+			end := strings.IndexByte(s, '>')
+			if end < 0 {
+				return errors.New("forge: unterminated HTML end tag")
+			}
+			a.end(tagName(s[2:end]))
+			s = s[end+1:]
+		case len(s) > 1 && isASCIILetter(s[1]):
+			rest, err := a.start(s[1:])
+			if err != nil {
+				return err
+			}
+			s = rest
+		default:
+			s = s[1:]
+		}
+	}
+}
+
+// start handles a start tag, s beginning at the tag name, and returns the
+// input after the tag and, for script and style, after their content.
+func (a *analyzer) start(s string) (string, error) {
+	name := tagName(s)
+	s = s[len(name):]
+	name = strings.ToLower(name)
+	attrs, s, selfClosing, err := parseAttrs(s)
+	if err != nil {
+		return "", err
+	}
+
+	a.c.Elements++
+	if riskyElements[name] {
+		a.risky("<" + name + ">")
+	}
+	if name == "script" {
+		a.c.Scripts++
+	}
+	if name == "meta" && strings.EqualFold(attrs["http-equiv"], "refresh") {
+		a.risky("meta refresh")
+	}
+	// Visit attributes in a fixed order, so that refs is deterministic.
+	keys := make([]string, 0, len(attrs))
+	for k := range attrs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		v := attrs[k]
+		switch {
+		case k == "srcset":
+			for _, u := range strings.Split(v, ",") {
+				a.url(strings.TrimSpace(u))
+			}
+		case resourceAttrs[k] || (k == "href" && name == "link"):
+			a.url(strings.TrimSpace(v))
+		case k == "href":
+			if isJavaScriptURL(v) {
+				a.risky("javascript: URL")
+			}
+		case k == "style":
+			a.css(v)
+		}
+	}
+
+	if !voidElements[name] && !selfClosing {
+		a.stack = append(a.stack, name)
+		a.open[name]++
+		a.c.MaxDepth = max(a.c.MaxDepth, len(a.stack))
+	}
+	if name == "script" || name == "style" {
+		end := indexFold(s, "</"+name)
+		if end < 0 {
+			return "", fmt.Errorf("forge: unterminated <%s> element", name)
+		}
+		if name == "style" {
+			a.css(s[:end])
+		}
+		s = s[end:]
+	}
+	return s, nil
+}
+
+// end pops the open element stack to the matching element, if any.
+func (a *analyzer) end(name string) {
+	name = strings.ToLower(name)
+	if a.open[name] == 0 {
+		return
+	}
+	for i := len(a.stack) - 1; i >= 0; i-- {
+		a.open[a.stack[i]]--
+		if a.stack[i] == name {
+			a.stack = a.stack[:i]
+			return
+		}
+	}
+}
+
+// url counts u if the browser would fetch it from the network.
+func (a *analyzer) url(u string) {
+	if f := strings.Fields(u); len(f) > 0 {
+		u = f[0] // srcset candidates carry a size descriptor
+	}
+	a.refs = append(a.refs, u)
+	lower := strings.ToLower(u)
+	switch {
+	case strings.HasPrefix(lower, "http://"), strings.HasPrefix(lower, "https://"), strings.HasPrefix(lower, "//"):
+		a.c.ExternalResources++
+	case isJavaScriptURL(u):
+		a.risky("javascript: URL")
+	}
+}
+
+// css counts the url() references and flags @import rules in a stylesheet.
+func (a *analyzer) css(s string) {
+	for rest := s; ; {
+		i := indexFold(rest, "@import")
+		if i < 0 {
+			break
+		}
+		a.risky("@import")
+		rest = strings.TrimLeft(rest[i+7:], " \t\r\n\f")
+		if rest != "" && (rest[0] == '"' || rest[0] == '\'') {
+			if end := strings.IndexByte(rest[1:], rest[0]); end >= 0 {
+				a.url(rest[1 : 1+end])
+			}
+		}
+	}
+	for {
+		i := indexFold(s, "url(")
+		if i < 0 {
+			return
+		}
+		s = s[i+4:]
+		end := strings.IndexByte(s, ')')
+		if end < 0 {
+			return
+		}
+		a.url(strings.Trim(strings.TrimSpace(s[:end]), `"'`))
+		s = s[end+1:]
+	}
+}
+
+// parseAttrs parses the attributes of a start tag up to and including its
+// closing '>'.
+func parseAttrs(s string) (attrs map[string]string, rest string, selfClosing bool, err error) {
+	attrs = map[string]string{}
+	for {
+		s = strings.TrimLeft(s, " \t\r\n\f")
+		if s == "" {
+			return nil, "", false, errors.New("forge: unterminated HTML tag")
+		}
+		switch {
+		case s[0] == '>':
+			return attrs, s[1:], selfClosing, nil
+		case s[0] == '/':
+			selfClosing = true
+			s = s[1:]
+			continue
+		}
+		selfClosing = false
+
+		n := strings.IndexAny(s, " \t\r\n\f=>/")
+		if n < 0 {
+			return nil, "", false, errors.New("forge: unterminated HTML tag")
+		}
+		if n == 0 {
+			n = 1 // stray character such as '=' without a name
+		}
+		key := strings.ToLower(s[:n])
+		s = strings.TrimLeft(s[n:], " \t\r\n\f")
+		if !strings.HasPrefix(s, "=") {
+			attrs[key] = ""
+			continue
+		}
+		s = strings.TrimLeft(s[1:], " \t\r\n\f")
+		var val string
+		if s != "" && (s[0] == '"' || s[0] == '\'') {
+			end := strings.IndexByte(s[1:], s[0])
+			if end < 0 {
+				return nil, "", false, errors.New("forge: unterminated HTML attribute")
+			}
+			val, s = s[1:1+end], s[2+end:]
+		} else {
+			end := strings.IndexAny(s, " \t\r\n\f>")
+			if end < 0 {
+				return nil, "", false, errors.New("forge: unterminated HTML tag")
+			}
+			val, s = s[:end], s[end:]
+		}
+		attrs[key] = val
+	}
+}
+
+// tagName returns the tag name at the start of s.
+func tagName(s string) string {
+	n := strings.IndexAny(s, " \t\r\n\f/>")
+	if n < 0 {
+		return s
+	}
+	return s[:n]
+}
+
+func isASCIILetter(b byte) bool {
+	return b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z'
+}
+
+func isJavaScriptURL(u string) bool {
+	u = strings.TrimSpace(u)
+	return len(u) >= 11 && strings.EqualFold(u[:11], "javascript:")
+}
+
+// indexFold is strings.Index ignoring ASCII case. substr must be
+// non-empty ASCII. Unlike lowercasing s first, it neither copies s nor
+// shifts offsets for non-ASCII input, so repeated calls over a document
+// stay linear.
+func indexFold(s, substr string) int {
+	n := len(substr)
+	first := lowerASCII(substr[0])
+	for i := 0; i+n <= len(s); i++ {
+		if lowerASCII(s[i]) != first {
+			continue
+		}
+		j := 1
+		for j < n && lowerASCII(s[i+j]) == lowerASCII(substr[j]) {
+			j++
+		}
+		if j == n {
+			return i
+		}
+	}
+	return -1
+}
+
+func lowerASCII(b byte) byte {
+	if b >= 'A' && b <= 'Z' {
+		return b + 'a' - 'A'
+	}
+	return b
+}
+
+// validateComplexity enforces the client's complexity limits on HTML.
+func (r *RenderRequest) validateComplexity() error {
+	l := r.client.limits
+	if l == nil || r.p.HTML == nil {
+		return nil
+	}
+	// Check the size before scanning, so an oversized document costs
+	// nothing to reject.
+	if n := len(*r.p.HTML); l.MaxBytes > 0 && n > l.MaxBytes {
+		return &ValidationError{Field: "html", Message: fmt.Sprintf("%d bytes exceeds the limit of %d", n, l.MaxBytes)}
+	}
+	c, err := AnalyzeHTML(*r.p.HTML)
+	if err != nil {
+		return &ValidationError{Field: "html", Message: strings.TrimPrefix(err.Error(), "forge: ")}
+	}
+	for _, check := range []struct {
+		what       string
+		got, limit int
+	}{
+		{"elements", c.Elements, l.MaxElements},
+		{"nesting depth", c.MaxDepth, l.MaxDepth},
+		{"external resources", c.ExternalResources, l.MaxExternalResources},
+		{"scripts", c.Scripts, l.MaxScripts},
+	} {
+		if check.limit > 0 && check.got > check.limit {
+			return &ValidationError{Field: "html", Message: fmt.Sprintf("%d %s exceeds the limit of %d", check.got, check.what, check.limit)}
+		}
+	}
+	if l.RejectRisky && len(c.Risky) > 0 {
+		return &ValidationError{Field: "html", Message: "contains " + strings.Join(c.Risky, ", ")}
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package forge
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAnalyzeHTML(t *testing.T) {
+	html := `<!DOCTYPE html>
+<html><head>
+<meta http-equiv="Refresh" content="0">
+<link rel=stylesheet href="https://cdn.example.com/a.css">
+<style>@import "b.css"; body { background: url('https://cdn.example.com/bg.png') }</style>
+<script>if (a < b) { document.write("<div>") }</script>
+</head>
+<body>
+<!-- <img src="https://ignored.example.com/x.png"> -->
+<div><p>Hello <b>world</b><br/>
+<img src="//img.example.com/1.png" srcset="https://img.example.com/1.png 1x, https://img.example.com/2.png 2x">
+<img src="logo.png">
+<a href="javascript:alert(1)">x</a>
+<iframe src="https://example.com/embed"></iframe>
+</p></div>
+</body></html>`
+
+	c, err := AnalyzeHTML(html)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Bytes != len(html) {
+		t.Errorf("Bytes = %d", c.Bytes)
+	}
+	if c.Elements != 15 {
+		t.Errorf("Elements = %d, want 15", c.Elements)
+	}
+	if c.MaxDepth != 5 { // html > body > div > p > b
+		t.Errorf("MaxDepth = %d, want 5", c.MaxDepth)
+	}
+	if c.ExternalResources != 6 {
+		t.Errorf("ExternalResources = %d, want 6", c.ExternalResources)
+	}
+	if c.Scripts != 1 {
+		t.Errorf("Scripts = %d", c.Scripts)
+	}
+	want := []string{"meta refresh", "@import", "javascript: URL", "<iframe>"}
+	if !reflect.DeepEqual(c.Risky, want) {
+		t.Errorf("Risky = %q, want %q", c.Risky, want)
+	}
+
+	for _, bad := range []string{"<p>x<!-- open", `<img src="x`, "<div class=a", "<script>forever"} {
+		if _, err := AnalyzeHTML(bad); err == nil {
+			t.Errorf("AnalyzeHTML(%q) succeeded", bad)
+		}
+	}
+}
+
+func TestComplexityLimits(t *testing.T) {
+	c := NewClient("http://localhost:3000", WithComplexityLimits(ComplexityLimits{MaxDepth: 3, RejectRisky: true}))
+
+	if err := c.RenderHTML("<div><div><div>x</div></div></div>").validate(); err != nil {
+		t.Errorf("within limits: %v", err)
+	}
+	for html, want := range map[string]string{
+		"<div><div><div><div>x</div></div></div></div>": "4 nesting depth exceeds the limit of 3",
+		`<object data="x.swf"></object>`:                "contains <object>",
+		"<p>x<!--":                                      "unterminated HTML comment",
+	} {
+		_, err := c.RenderHTML(html).Send(context.Background())
+		if ve, ok := err.(*ValidationError); !ok || ve.Field != "html" || !strings.Contains(ve.Message, want) {
+			t.Errorf("%s: err = %v, want %q", html, err, want)
+		}
+	}
+
+	// URL renders are not analyzed.
+	if err := c.RenderURL("https://example.com").validate(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestAnalyzeHTMLLinear(t *testing.T) {
+	for name, doc := range map[string]func(n int) string{
+		// Each style element searches the rest of the document for its
+		// end tag; that search must not copy the remainder.
+		"style": func(n int) string { return strings.Repeat("<style>a{}</STYLE>", n) },
+		// Unmatched end tags must not search the open element stack.
+		"unmatched end tags": func(n int) string {
+			return strings.Repeat("<div>", n) + strings.Repeat("</span>", n)
+		},
+	} {
+		elapsed := func(n int) time.Duration {
+			html := doc(n)
+			best := time.Duration(1<<63 - 1)
+			for i := 0; i < 3; i++ {
+				start := time.Now()
+				if _, err := AnalyzeHTML(html); err != nil {
+					t.Fatal(err)
+				}
+				best = min(best, time.Since(start))
+			}
+			return best
+		}
+		small, large := elapsed(5000), elapsed(40000)
+		// Linear scaling gives a ratio of about 8; quadratic about 64.
+		if large > 24*small {
+			t.Errorf("%s: 8x input took %v vs %v; AnalyzeHTML is not linear", name, large, small)
+		}
+	}
+}
+
+func TestComplexityLimitsMaxBytesFirst(t *testing.T) {
+	c := NewClient("http://localhost:3000", WithComplexityLimits(ComplexityLimits{MaxBytes: 10}))
+	// The unterminated comment is never scanned: the size check wins.
+	err := c.RenderHTML("<p>too long<!--").validate()
+	if ve, ok := err.(*ValidationError); !ok || ve.Message != "15 bytes exceeds the limit of 10" {
+		t.Errorf("err = %v", err)
+	}
+}
@@ -0,0 +1,45 @@
+package forge
+
+// APIVersion is the newest server API version whose payload schema this
+// SDK produces.
+const APIVersion = "2025-06"
+
+// WithAPIVersion pins every request to a server API version ("YYYY-MM")
+// via the X-Forge-API-Version header. The server then interprets payloads
+// with that version's schema, so upgrading the SDK or the server cannot
+// silently change what a field means. Versions newer than APIVersion, or
+// malformed ones, fail every request with a *ValidationError.
+func WithAPIVersion(version string) Option {
+	return func(c *Client) {
+		c.apiVersion = version
+	}
+}
+
+// validAPIVersion reports whether v has the form YYYY-MM.
+func validAPIVersion(v string) bool {
+	if len(v) != 7 || v[4] != '-' {
+		return false
+	}
+	for i, c := range v {
+		if i != 4 && (c < '0' || c > '9') {
+			return false
+		}
+	}
+	return v[5:] >= "01" && v[5:] <= "12"
+}
+
+// validateAPIVersion rejects a malformed pinned version, or one newer than
+// the SDK knows.
+func (c *Client) validateAPIVersion() error {
+	v := c.apiVersion
+	if v == "" {
+		return nil
+	}
+	if !validAPIVersion(v) {
+		return &ValidationError{Field: "api_version", Message: "must have the form YYYY-MM, got " + v}
+	}
+	if v > APIVersion {
+		return &ValidationError{Field: "api_version", Message: v + " is newer than this SDK supports (" + APIVersion + ")"}
+	}
+	return nil
+}
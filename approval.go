@@ -0,0 +1,84 @@
+package forge
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+)
+
+// Preview is a render prepared for approval. Data is the exact output the
+// request produces; Fingerprint identifies the request that produced it.
+type Preview struct {
+	Data        []byte
+	Warnings    []string
+	Fingerprint string
+}
+
+// Fingerprint returns a stable "sha256:<hex>" digest of the request's
+// payload and Accept header. Two requests with the same fingerprint render
+// the same document.
+func (r *RenderRequest) Fingerprint() (string, error) {
+	cr, err := r.compile()
+	if err != nil {
+		return "", err
+	}
+	return cr.fingerprint()
+}
+
+// fingerprint digests the snapshot's payload and Accept header.
+func (cr *compiled) fingerprint() (string, error) {
+	body, err := json.Marshal(cr.p)
+	if err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	h.Write(body)
+	if cr.accept != "" {
+		h.Write([]byte("\nAccept: " + cr.accept))
+	}
+	return "sha256:" + hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// Prepare renders the request for review and returns the output together
+// with the request's fingerprint. Store the fingerprint with the approval
+// and pass it to Commit to render the final document.
+func (r *RenderRequest) Prepare(ctx context.Context) (*Preview, error) {
+	cr, err := r.compile()
+	if err != nil {
+		return nil, err
+	}
+	fp, err := cr.fingerprint()
+	if err != nil {
+		return nil, err
+	}
+	res, err := cr.sendWithWarnings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Preview{Data: res.Data, Warnings: res.Warnings, Fingerprint: fp}, nil
+}
+
+// Commit executes the final render only if the request still matches the
+// approved fingerprint, returning *FingerprintMismatchError otherwise.
+// The request may be rebuilt in another process; only its content matters.
+// The fingerprint is checked against the same snapshot that is sent, so
+// later changes to slices or maps passed to the builder cannot slip in.
+func (r *RenderRequest) Commit(ctx context.Context, fingerprint string) ([]byte, error) {
+	cr, err := r.compile()
+	if err != nil {
+		return nil, err
+	}
+	fp, err := cr.fingerprint()
+	if err != nil {
+		return nil, err
+	}
+	if fp != fingerprint {
+		return nil, &FingerprintMismatchError{Approved: fingerprint, Actual: fp}
+	}
+	res, err := cr.sendWithWarnings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return res.Data, nil
+}
@@ -0,0 +1,48 @@
+package forge
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPrepareCommit(t *testing.T) {
+	var renders int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		renders++
+		w.Write([]byte("%PDF"))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+	build := func() *RenderRequest {
+		return c.RenderHTML("<h1>Contract</h1>").PdfTitle("Contract")
+	}
+	preview, err := build().Prepare(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(preview.Data) != "%PDF" || len(preview.Fingerprint) != len("sha256:")+64 {
+		t.Errorf("preview = %+v", preview)
+	}
+
+	if _, err := build().Commit(context.Background(), preview.Fingerprint); err != nil {
+		t.Fatal(err)
+	}
+	if renders != 2 {
+		t.Errorf("renders = %d, want 2", renders)
+	}
+
+	_, err = build().PdfAuthor("Mallory").Commit(context.Background(), preview.Fingerprint)
+	fe, ok := err.(*FingerprintMismatchError)
+	if !ok {
+		t.Fatalf("err = %v, want *FingerprintMismatchError", err)
+	}
+	if fe.Approved != preview.Fingerprint || fe.Actual == fe.Approved {
+		t.Errorf("err = %+v", fe)
+	}
+	if renders != 2 {
+		t.Errorf("mismatched commit reached the server")
+	}
+}
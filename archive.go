@@ -0,0 +1,107 @@
+package forge
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ArchiveSource is a zip archive of web assets, rendered from its entry
+// file by the server's archive endpoint.
+type ArchiveSource struct {
+	// Entry is the path of the HTML file to render within the archive.
+	Entry string `json:"entry"`
+	// Data is the zip archive, base64-encoded in JSON payloads.
+	Data []byte `json:"data,omitempty"`
+}
+
+// RenderDir starts a render request for a directory of web assets, such
+// as a generated static site. The directory is zipped and the server
+// renders entryFile, a path relative to dir, resolving relative URLs
+// within the archive. Hidden files and directories, such as .git, are
+// left out. Read errors are reported by the request's Send methods.
+func (c *Client) RenderDir(dir, entryFile string) *RenderRequest {
+	r := &RenderRequest{client: c}
+	entry := path.Clean(filepath.ToSlash(entryFile))
+	if entryFile == "" || path.IsAbs(entry) || entry == ".." || strings.HasPrefix(entry, "../") {
+		r.fail("archive.entry", "%q must be a relative path", entryFile)
+		return r
+	}
+	if fi, err := os.Stat(filepath.Join(dir, filepath.FromSlash(entry))); err != nil || !fi.Mode().IsRegular() {
+		r.fail("archive.entry", "%q is not a file in %s", entryFile, dir)
+		return r
+	}
+	data, err := zipDir(os.DirFS(dir))
+	if err != nil {
+		r.fail("archive", "%v", err)
+		return r
+	}
+	r.p.Archive = &ArchiveSource{Entry: entry, Data: data}
+	return r
+}
+
+// zipDir archives the regular files of fsys, skipping hidden ones.
+func zipDir(fsys fs.FS) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	err := fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if name != "." && strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		f, err := fsys.Open(name)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate})
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(w, f)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// renderPath returns the endpoint that renders p.
+func renderPath(p *RenderPayload) string {
+	if p.Archive != nil {
+		return "/render/archive"
+	}
+	return "/render"
+}
+
+// validateArchive rejects archives combined with another document source
+// or with separately shipped assets.
+func (r *RenderRequest) validateArchive() error {
+	if r.p.Archive == nil {
+		return nil
+	}
+	if r.p.HTML != nil || r.p.URL != nil || r.p.Template != nil {
+		return &ValidationError{Field: "archive", Message: "cannot be combined with html, url, or template"}
+	}
+	if len(r.p.Assets) > 0 || len(r.p.Fonts) > 0 {
+		return &ValidationError{Field: "assets", Message: "add files to the archive directory instead"}
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package forge
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestRenderDir(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"site/index.html":      "<h1>Docs</h1>",
+		"site/css/site.css":    "h1{}",
+		"site/.git/HEAD":       "ref",
+		"site/.env":            "SECRET=1",
+		"site/guide/intro.htm": "<p>Intro</p>",
+	})
+	var path string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		w.Write([]byte("%PDF-1.7"))
+	}))
+	defer srv.Close()
+	c := NewClient(srv.URL)
+
+	r := c.RenderDir(filepath.Join(dir, "site"), "guide/../index.html")
+	if _, err := r.Send(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if path != "/render/archive" {
+		t.Errorf("path = %q", path)
+	}
+	a := r.Payload().Archive
+	if a.Entry != "index.html" {
+		t.Errorf("entry = %q", a.Entry)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(a.Data), int64(len(a.Data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+		if f.Name == "css/site.css" {
+			rc, _ := f.Open()
+			if data, _ := io.ReadAll(rc); string(data) != "h1{}" {
+				t.Errorf("css/site.css = %q", data)
+			}
+			rc.Close()
+		}
+	}
+	sort.Strings(names)
+	if want := []string{"css/site.css", "guide/intro.htm", "index.html"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("archive files = %v, want %v", names, want)
+	}
+
+	for _, tc := range []struct {
+		r     *RenderRequest
+		field string
+	}{
+		{c.RenderDir(filepath.Join(dir, "site"), "missing.html"), "archive.entry"},
+		{c.RenderDir(filepath.Join(dir, "site"), "../site/index.html"), "archive.entry"},
+		{c.RenderDir(filepath.Join(dir, "site"), "index.html").Asset("x.css", nil, ""), "assets"},
+	} {
+		err := tc.r.validate()
+		if ve, ok := err.(*ValidationError); !ok || ve.Field != tc.field {
+			t.Errorf("err = %v, want field %s", err, tc.field)
+		}
+	}
+}
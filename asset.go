@@ -0,0 +1,44 @@
+package forge
+
+import (
+	"mime"
+	"path"
+	"strings"
+)
+
+// Asset is a file shipped with the HTML of a render request, such as an
+// image, stylesheet, or font, resolvable from the HTML by relative URL.
+type Asset struct {
+	// Name is the relative path the HTML references, e.g. "img/logo.png".
+	Name     string `json:"name"`
+	MimeType string `json:"mime_type"`
+	// Data is the file content, base64-encoded in JSON payloads.
+	Data []byte `json:"data"`
+}
+
+// Asset ships a file alongside the HTML so that relative URLs such as
+// <img src="img/logo.png"> resolve to it during rendering. An empty
+// mimeType is inferred from the name's extension. It can be called
+// repeatedly; names must be unique.
+func (r *RenderRequest) Asset(name string, data []byte, mimeType string) *RenderRequest {
+	clean := path.Clean(name)
+	if name == "" || path.IsAbs(name) || clean == ".." || strings.HasPrefix(clean, "../") || strings.Contains(name, "://") {
+		r.fail("assets", "name %q must be a relative path", name)
+		return r
+	}
+	if mimeType == "" {
+		mimeType = mime.TypeByExtension(path.Ext(name))
+		if mimeType == "" {
+			r.fail("assets", "cannot infer the MIME type of %q", name)
+			return r
+		}
+	}
+	for _, a := range r.p.Assets {
+		if a.Name == clean {
+			r.fail("assets", "duplicate asset %q", clean)
+			return r
+		}
+	}
+	r.p.Assets = append(r.p.Assets, Asset{Name: clean, MimeType: mimeType, Data: data})
+	return r
+}
@@ -0,0 +1,168 @@
+package forge
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Validate checks the barcode's data against its symbology: length,
+// character set, and check digit where the symbology has one. It catches
+// data that would render as an unscannable or rejected barcode before a
+// large batch is sent.
+func (b BarcodeConfig) Validate() error {
+	if b.Data == "" {
+		return barcodeError("data", "must not be empty")
+	}
+	switch b.Type {
+	case BarcodeQR:
+		if err := maxLen(b.Data, 2953); err != nil {
+			return err
+		}
+	case BarcodeDataMatrix:
+		if err := maxLen(b.Data, 2335); err != nil {
+			return err
+		}
+	case BarcodePDF417:
+		if err := maxLen(b.Data, 1850); err != nil {
+			return err
+		}
+	case BarcodeAztec:
+		if err := maxLen(b.Data, 3067); err != nil {
+			return err
+		}
+	case BarcodeCode128, BarcodeCode93:
+		if err := charset(b.Data, isASCII, "ASCII characters"); err != nil {
+			return err
+		}
+		if err := maxLen(b.Data, 80); err != nil {
+			return err
+		}
+	case BarcodeEAN13:
+		if err := gtin(b.Data, 13); err != nil {
+			return err
+		}
+	case BarcodeEAN8:
+		if err := gtin(b.Data, 8); err != nil {
+			return err
+		}
+	case BarcodeUPCA:
+		if err := gtin(b.Data, 12); err != nil {
+			return err
+		}
+	case BarcodeCode39:
+		if err := charset(b.Data, inSet("0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ-. $/+%"), "digits, uppercase letters, and -. $/+%"); err != nil {
+			return err
+		}
+	case BarcodeCodabar:
+		data := b.Data
+		if len(data) >= 2 && strings.ContainsRune("ABCD", rune(data[0])) && strings.ContainsRune("ABCD", rune(data[len(data)-1])) {
+			data = data[1 : len(data)-1]
+		}
+		if err := charset(data, inSet("0123456789-$:/.+"), "digits and -$:/.+ between optional A-D start/stop characters"); err != nil {
+			return err
+		}
+	case BarcodeITF:
+		if err := charset(b.Data, isDigit, "digits"); err != nil {
+			return err
+		}
+		if len(b.Data)%2 != 0 {
+			return barcodeError("data", fmt.Sprintf("ITF needs an even number of digits, got %d", len(b.Data)))
+		}
+	case BarcodeCode11:
+		if err := charset(b.Data, inSet("0123456789-"), "digits and -"); err != nil {
+			return err
+		}
+	default:
+		return barcodeError("type", fmt.Sprintf("unknown barcode type %q", b.Type))
+	}
+
+	if b.Width != nil && *b.Width <= 0 {
+		return barcodeError("width", fmt.Sprintf("must be positive, got %g", *b.Width))
+	}
+	if b.Height != nil && *b.Height <= 0 {
+		return barcodeError("height", fmt.Sprintf("must be positive, got %g", *b.Height))
+	}
+	return nil
+}
+
+// PreviewBarcode renders cfg on its own as a PNG image, so operators can
+// test scannability before embedding it in a document run. Only the
+// symbology, data, size, and colors of cfg apply.
+func (c *Client) PreviewBarcode(ctx context.Context, cfg BarcodeConfig) ([]byte, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return c.postJSON(ctx, "/barcode", cfg)
+}
+
+// validateBarcodes checks every barcode added to the request.
+func (r *RenderRequest) validateBarcodes() error {
+	if r.p.Pdf == nil {
+		return nil
+	}
+	for _, b := range r.p.Pdf.Barcodes {
+		if err := b.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func barcodeError(field, msg string) *ValidationError {
+	return &ValidationError{Field: "barcode." + field, Message: msg}
+}
+
+func maxLen(data string, n int) error {
+	if len(data) > n {
+		return barcodeError("data", fmt.Sprintf("%d bytes exceeds the symbology maximum of %d", len(data), n))
+	}
+	return nil
+}
+
+func charset(data string, ok func(rune) bool, allowed string) error {
+	for i, c := range data {
+		if !ok(c) {
+			return barcodeError("data", fmt.Sprintf("invalid character %q at offset %d; allowed: %s", c, i, allowed))
+		}
+	}
+	return nil
+}
+
+// gtin checks an EAN/UPC number of n digits, or n-1 digits with the check
+// digit left for the server to compute.
+func gtin(data string, n int) error {
+	if err := charset(data, isDigit, "digits"); err != nil {
+		return err
+	}
+	if len(data) != n && len(data) != n-1 {
+		return barcodeError("data", fmt.Sprintf("needs %d digits (or %d without check digit), got %d", n, n-1, len(data)))
+	}
+	if len(data) == n-1 {
+		return nil
+	}
+	if want := checkDigit(data[:n-1]); data[n-1] != want {
+		return barcodeError("data", fmt.Sprintf("check digit is %c, want %c", data[n-1], want))
+	}
+	return nil
+}
+
+// checkDigit computes the GS1 mod-10 check digit of digits.
+func checkDigit(digits string) byte {
+	sum := 0
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if (len(digits)-1-i)%2 == 0 {
+			d *= 3
+		}
+		sum += d
+	}
+	return byte('0' + (10-sum%10)%10)
+}
+
+func isASCII(c rune) bool { return c < 128 }
+func isDigit(c rune) bool { return c >= '0' && c <= '9' }
+
+func inSet(set string) func(rune) bool {
+	return func(c rune) bool { return strings.ContainsRune(set, c) }
+}
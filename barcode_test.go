@@ -0,0 +1,79 @@
+package forge
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestBarcodeValidate(t *testing.T) {
+	valid := []BarcodeConfig{
+		{Type: BarcodeQR, Data: "https://example.com/invoice/123"},
+		{Type: BarcodeEAN13, Data: "4006381333931"},
+		{Type: BarcodeEAN13, Data: "400638133393"},
+		{Type: BarcodeEAN8, Data: "96385074"},
+		{Type: BarcodeUPCA, Data: "036000291452"},
+		{Type: BarcodeCode39, Data: "ABC-123"},
+		{Type: BarcodeCodabar, Data: "A40156B"},
+		{Type: BarcodeITF, Data: "1234"},
+		{Type: BarcodeCode128, Data: "Order #42"},
+	}
+	for _, b := range valid {
+		if err := b.Validate(); err != nil {
+			t.Errorf("%s %q: %v", b.Type, b.Data, err)
+		}
+	}
+
+	w := 0.0
+	invalid := []BarcodeConfig{
+		{Type: BarcodeQR, Data: ""},
+		{Type: BarcodeEAN13, Data: "4006381333932"},
+		{Type: BarcodeEAN13, Data: "40063813339"},
+		{Type: BarcodeUPCA, Data: "03600029145X"},
+		{Type: BarcodeCode39, Data: "abc"},
+		{Type: BarcodeITF, Data: "123"},
+		{Type: BarcodeCode128, Data: "café"},
+		{Type: BarcodeQR, Data: "x", Width: &w},
+		{Type: "maxicode", Data: "x"},
+	}
+	for _, b := range invalid {
+		if _, ok := b.Validate().(*ValidationError); !ok {
+			t.Errorf("%s %q: want *ValidationError", b.Type, b.Data)
+		}
+	}
+}
+
+func TestBarcodeValidatedOnSend(t *testing.T) {
+	c := NewClient("http://localhost:3000")
+	_, err := c.RenderHTML("x").PdfBarcode(BarcodeEAN13, "4006381333932").Send(context.Background())
+	if ve, ok := err.(*ValidationError); !ok || ve.Field != "barcode.data" {
+		t.Errorf("err = %v, want barcode.data *ValidationError", err)
+	}
+}
+
+func TestPreviewBarcode(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/barcode" {
+			t.Errorf("request = %s %s", r.Method, r.URL.Path)
+		}
+		var b BarcodeConfig
+		if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
+			t.Fatal(err)
+		}
+		if b.Type != BarcodeQR || b.Data != "hello" {
+			t.Errorf("barcode = %+v", b)
+		}
+		w.Write([]byte("\x89PNG"))
+	}))
+	defer srv.Close()
+
+	png, err := NewClient(srv.URL).PreviewBarcode(context.Background(), BarcodeConfig{Type: BarcodeQR, Data: "hello"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(png) != "\x89PNG" {
+		t.Errorf("png = %q", png)
+	}
+}
@@ -0,0 +1,178 @@
+// Package charts renders bar, line, and pie charts through Forge.
+//
+// A Spec is compiled into a self-contained HTML page whose inline script
+// draws the chart as SVG. Output is deterministic: series colors and
+// Math.random are derived from Spec.Seed, and the chart is drawn before the
+// page's load event, so Forge never captures a half-drawn chart. When
+// drawing completes the body gets a data-chart-ready attribute, which
+// Render waits for and other tools can wait for too.
+package charts
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+
+	forge "github.com/centrixsystems/forge-sdk-go"
+)
+
+// Kind is a chart type.
+type Kind string
+
+const (
+	Bar  Kind = "bar"
+	Line Kind = "line"
+	Pie  Kind = "pie"
+)
+
+// ReadySelector matches the page once the chart has been drawn.
+const ReadySelector = "body[data-chart-ready]"
+
+// Series is one named data series. Values align with Spec.Labels.
+type Series struct {
+	Name   string    `json:"name"`
+	Values []float64 `json:"values"`
+	// Color is a CSS color. Empty picks one from Spec.Colors or the seed.
+	Color string `json:"color"`
+}
+
+// Spec describes a chart.
+type Spec struct {
+	Kind  Kind
+	Title string
+	// Labels name the categories: x-axis ticks for bar and line charts,
+	// slices for pie charts.
+	Labels []string
+	// Series holds the data. Pie charts take exactly one series.
+	Series []Series
+	// Width and Height are the chart size in CSS pixels (default 800x450).
+	Width  int
+	Height int
+	// Colors is the palette assigned to series (pie: slices) in order.
+	// Entries beyond it are generated from Seed.
+	Colors []string
+	// Seed makes generated colors and Math.random reproducible.
+	Seed int64
+}
+
+// defaultColors is the palette used when Spec.Colors is empty.
+var defaultColors = []string{"#4e79a7", "#f28e2b", "#e15759", "#76b7b2", "#59a14f", "#edc948", "#b07aa1", "#ff9da7"}
+
+// HTML compiles the spec into a standalone HTML page.
+func HTML(spec Spec) (string, error) {
+	if err := spec.validate(); err != nil {
+		return "", err
+	}
+	if spec.Width == 0 {
+		spec.Width = 800
+	}
+	if spec.Height == 0 {
+		spec.Height = 450
+	}
+
+	palette := spec.Colors
+	if len(palette) == 0 {
+		palette = defaultColors
+	}
+	rng := rand.New(rand.NewSource(spec.Seed))
+	color := func(i int) string {
+		if i < len(palette) {
+			return palette[i]
+		}
+		return fmt.Sprintf("hsl(%d,60%%,50%%)", rng.Intn(360))
+	}
+	series := make([]Series, len(spec.Series))
+	copy(series, spec.Series)
+	var colors []string
+	if spec.Kind == Pie {
+		for i := range spec.Labels {
+			colors = append(colors, color(i))
+		}
+	} else {
+		for i := range series {
+			if series[i].Color == "" {
+				series[i].Color = color(i)
+			}
+		}
+	}
+
+	data, err := json.Marshal(struct {
+		Kind   Kind     `json:"kind"`
+		Title  string   `json:"title"`
+		Labels []string `json:"labels"`
+		Series []Series `json:"series"`
+		Colors []string `json:"colors"`
+		Width  int      `json:"width"`
+		Height int      `json:"height"`
+		Seed   uint32   `json:"seed"`
+	}{spec.Kind, spec.Title, spec.Labels, series, colors, spec.Width, spec.Height, uint32(spec.Seed)})
+	if err != nil {
+		return "", fmt.Errorf("charts: %w", err)
+	}
+
+	var b strings.Builder
+	b.WriteString("<!DOCTYPE html>\n<html><head><meta charset=\"utf-8\"><style>")
+	b.WriteString("html,body{margin:0}body{font-family:sans-serif}svg text{font-size:12px;fill:#333}")
+	b.WriteString("</style></head><body>\n<div id=\"chart\"></div>\n")
+	b.WriteString("<script type=\"application/json\" id=\"chart-spec\">")
+	b.Write(data) // json.Marshal escapes <, >, and &, so the data cannot end the script.
+	b.WriteString("</script>\n<script>\n")
+	b.WriteString(drawScript)
+	b.WriteString("</script>\n</body></html>\n")
+	return b.String(), nil
+}
+
+// Render compiles the spec and starts a render request for it, sized to
+// the chart. The request can be configured further before sending.
+func Render(c *forge.Client, spec Spec) (*forge.RenderRequest, error) {
+	page, err := HTML(spec)
+	if err != nil {
+		return nil, err
+	}
+	w, h := spec.Width, spec.Height
+	if w == 0 {
+		w = 800
+	}
+	if h == 0 {
+		h = 450
+	}
+	return c.RenderHTML(page).Width(w).Height(h).WaitForSelector(ReadySelector), nil
+}
+
+func (s Spec) validate() error {
+	switch s.Kind {
+	case Bar, Line, Pie:
+	default:
+		return fmt.Errorf("charts: unknown kind %q", s.Kind)
+	}
+	if len(s.Series) == 0 {
+		return fmt.Errorf("charts: no series")
+	}
+	if s.Kind == Pie && len(s.Series) != 1 {
+		return fmt.Errorf("charts: pie charts take one series, got %d", len(s.Series))
+	}
+	if s.Width < 0 || s.Height < 0 {
+		return fmt.Errorf("charts: negative size %dx%d", s.Width, s.Height)
+	}
+	for _, ser := range s.Series {
+		if len(ser.Values) != len(s.Labels) {
+			return fmt.Errorf("charts: series %q has %d values for %d labels", ser.Name, len(ser.Values), len(s.Labels))
+		}
+		sum := 0.0
+		for _, v := range ser.Values {
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				return fmt.Errorf("charts: series %q has a non-finite value", ser.Name)
+			}
+			if s.Kind == Pie && v < 0 {
+				return fmt.Errorf("charts: pie values must not be negative")
+			}
+			sum += v
+		}
+		if s.Kind == Pie && sum == 0 {
+			return fmt.Errorf("charts: pie values sum to zero")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package charts
+
+import (
+	"strings"
+	"testing"
+
+	forge "github.com/centrixsystems/forge-sdk-go"
+)
+
+func TestHTMLDeterministic(t *testing.T) {
+	spec := Spec{
+		Kind:   Bar,
+		Title:  "Revenue </script><b>",
+		Labels: []string{"Q1", "Q2"},
+		Series: []Series{{Name: "2025", Values: []float64{1, 2}}, {Name: "2026", Values: []float64{2, 3}}},
+		Colors: []string{"#111111"},
+		Seed:   42,
+	}
+	a, err := HTML(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := HTML(spec)
+	if a != b {
+		t.Error("HTML is not deterministic for a fixed seed")
+	}
+	if strings.Count(a, "</script>") != 2 {
+		t.Error("title was not escaped inside the spec script")
+	}
+	if !strings.Contains(a, `"color":"#111111"`) || !strings.Contains(a, `"color":"hsl(`) {
+		t.Error("series colors not assigned from palette and seed")
+	}
+}
+
+func TestSpecValidation(t *testing.T) {
+	for _, spec := range []Spec{
+		{Kind: "radar", Labels: []string{"a"}, Series: []Series{{Values: []float64{1}}}},
+		{Kind: Bar, Labels: []string{"a"}},
+		{Kind: Line, Labels: []string{"a", "b"}, Series: []Series{{Values: []float64{1}}}},
+		{Kind: Pie, Labels: []string{"a"}, Series: []Series{{Values: []float64{1}}, {Values: []float64{1}}}},
+		{Kind: Pie, Labels: []string{"a", "b"}, Series: []Series{{Values: []float64{1, -1}}}},
+	} {
+		if _, err := HTML(spec); err == nil {
+			t.Errorf("HTML(%+v) succeeded, want error", spec)
+		}
+	}
+}
+
+func TestRender(t *testing.T) {
+	c := forge.NewClient("http://localhost:3000")
+	r, err := Render(c, Spec{Kind: Pie, Labels: []string{"a", "b"}, Series: []Series{{Values: []float64{1, 3}}}, Width: 400})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := r.Payload()
+	if *p.Width != 400 || *p.Height != 450 {
+		t.Errorf("size = %dx%d", *p.Width, *p.Height)
+	}
+	if !strings.Contains(*p.HTML, "data-chart-ready") {
+		t.Error("ready marker missing")
+	}
+	if p.Wait == nil || p.Wait.Selector != ReadySelector {
+		t.Errorf("wait = %+v", p.Wait)
+	}
+}
@@ -0,0 +1,112 @@
+package charts
+
+// drawScript draws the chart described by the #chart-spec JSON as SVG.
+// It runs synchronously, so the chart is complete before the load event.
+const drawScript = `(function () {
+  var spec = JSON.parse(document.getElementById("chart-spec").textContent);
+
+  // mulberry32, so scripts that use Math.random render reproducibly.
+  var state = spec.seed >>> 0;
+  Math.random = function () {
+    state = (state + 0x6D2B79F5) >>> 0;
+    var t = state;
+    t = Math.imul(t ^ (t >>> 15), t | 1);
+    t ^= t + Math.imul(t ^ (t >>> 7), t | 61);
+    return ((t ^ (t >>> 14)) >>> 0) / 4294967296;
+  };
+
+  var NS = "http://www.w3.org/2000/svg";
+  var W = spec.width, H = spec.height;
+  var svg = document.createElementNS(NS, "svg");
+  svg.setAttribute("width", W);
+  svg.setAttribute("height", H);
+  svg.setAttribute("viewBox", "0 0 " + W + " " + H);
+
+  function el(name, attrs, text) {
+    var e = document.createElementNS(NS, name);
+    for (var k in attrs) e.setAttribute(k, attrs[k]);
+    if (text != null) e.textContent = text;
+    svg.appendChild(e);
+    return e;
+  }
+
+  var top = spec.title ? 40 : 16;
+  if (spec.title) {
+    el("text", {x: W / 2, y: 24, "text-anchor": "middle", style: "font-size:16px;font-weight:bold"}, spec.title);
+  }
+
+  if (spec.kind === "pie") {
+    var values = spec.series[0].values;
+    var total = values.reduce(function (a, b) { return a + b; }, 0);
+    var cx = W / 2, cy = (top + H) / 2, r = Math.min(W, H - top) / 2 - 30;
+    var angle = -Math.PI / 2;
+    values.forEach(function (v, i) {
+      var sweep = v / total * 2 * Math.PI;
+      var end = angle + sweep;
+      var x1 = cx + r * Math.cos(angle), y1 = cy + r * Math.sin(angle);
+      var x2 = cx + r * Math.cos(end), y2 = cy + r * Math.sin(end);
+      if (sweep >= 2 * Math.PI - 1e-9) {
+        el("circle", {cx: cx, cy: cy, r: r, fill: spec.colors[i]});
+      } else if (sweep > 0) {
+        el("path", {
+          d: "M" + cx + "," + cy + " L" + x1 + "," + y1 +
+            " A" + r + "," + r + " 0 " + (sweep > Math.PI ? 1 : 0) + " 1 " + x2 + "," + y2 + " Z",
+          fill: spec.colors[i], stroke: "#fff"
+        });
+      }
+      var mid = angle + sweep / 2;
+      el("text", {x: cx + (r + 16) * Math.cos(mid), y: cy + (r + 16) * Math.sin(mid),
+        "text-anchor": Math.cos(mid) < 0 ? "end" : "start", "dominant-baseline": "middle"}, spec.labels[i]);
+      angle = end;
+    });
+  } else {
+    var left = 60, right = W - 20, bottom = H - 40;
+    var plotW = right - left, plotH = bottom - top;
+    var max = 0, min = 0;
+    spec.series.forEach(function (s) {
+      s.values.forEach(function (v) { max = Math.max(max, v); min = Math.min(min, v); });
+    });
+    if (max === min) max = min + 1;
+    var y = function (v) { return top + (max - v) / (max - min) * plotH; };
+    var n = spec.labels.length, groupW = plotW / Math.max(n, 1);
+
+    for (var t = 0; t <= 4; t++) {
+      var v = min + (max - min) * t / 4;
+      el("line", {x1: left, x2: right, y1: y(v), y2: y(v), stroke: "#e0e0e0"});
+      el("text", {x: left - 6, y: y(v), "text-anchor": "end", "dominant-baseline": "middle"},
+        Number(v.toPrecision(4)).toString());
+    }
+    el("line", {x1: left, x2: right, y1: y(0), y2: y(0), stroke: "#333"});
+    spec.labels.forEach(function (label, i) {
+      el("text", {x: left + (i + 0.5) * groupW, y: bottom + 18, "text-anchor": "middle"}, label);
+    });
+
+    spec.series.forEach(function (s, si) {
+      if (spec.kind === "bar") {
+        var barW = groupW * 0.8 / spec.series.length;
+        s.values.forEach(function (v, i) {
+          el("rect", {x: left + i * groupW + groupW * 0.1 + si * barW, y: Math.min(y(v), y(0)),
+            width: barW, height: Math.abs(y(v) - y(0)), fill: s.color});
+        });
+      } else {
+        var pts = s.values.map(function (v, i) { return (left + (i + 0.5) * groupW) + "," + y(v); });
+        el("polyline", {points: pts.join(" "), fill: "none", stroke: s.color, "stroke-width": 2});
+        s.values.forEach(function (v, i) {
+          el("circle", {cx: left + (i + 0.5) * groupW, cy: y(v), r: 3, fill: s.color});
+        });
+      }
+    });
+
+    if (spec.series.length > 1) {
+      spec.series.forEach(function (s, si) {
+        var ly = top + si * 18;
+        el("rect", {x: right - 110, y: ly, width: 10, height: 10, fill: s.color});
+        el("text", {x: right - 95, y: ly + 5, "dominant-baseline": "middle"}, s.name);
+      });
+    }
+  }
+
+  document.getElementById("chart").appendChild(svg);
+  document.body.setAttribute("data-chart-ready", "true");
+})();
+`
@@ -0,0 +1,76 @@
+// Command forgectl is an operational tool for Forge servers.
+//
+// Usage:
+//
+//	forgectl load -server URL -corpus DIR [-rps N] [-duration D] [-concurrency N]
+//
+// The load subcommand replays the RenderPayload JSON files in DIR at the
+// target rate and prints latency percentiles and error rates.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	forge "github.com/centrixsystems/forge-sdk-go"
+	"github.com/centrixsystems/forge-sdk-go/forgeload"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+	switch os.Args[1] {
+	case "load":
+		if err := runLoad(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, "forgectl:", err)
+			os.Exit(1)
+		}
+	default:
+		usage()
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: forgectl load -server URL -corpus DIR [-rps N] [-duration D] [-concurrency N]")
+	os.Exit(2)
+}
+
+func runLoad(args []string) error {
+	fs := flag.NewFlagSet("load", flag.ExitOnError)
+	server := fs.String("server", "http://localhost:3000", "Forge server base URL")
+	corpusDir := fs.String("corpus", "", "directory of RenderPayload JSON files")
+	rps := fs.Float64("rps", 1, "target requests per second")
+	duration := fs.Duration("duration", time.Minute, "how long to generate load")
+	concurrency := fs.Int("concurrency", 64, "maximum in-flight requests")
+	timeout := fs.Duration("timeout", 120*time.Second, "per-request HTTP timeout")
+	fs.Parse(args)
+
+	if *corpusDir == "" {
+		return fmt.Errorf("-corpus is required")
+	}
+
+	client := forge.NewClient(*server, forge.WithTimeout(*timeout))
+	corpus, err := forgeload.LoadCorpus(client, *corpusDir)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	rep, err := forgeload.Run(ctx, corpus, forgeload.Options{
+		RPS:         *rps,
+		Duration:    *duration,
+		Concurrency: *concurrency,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = rep.WriteTo(os.Stdout)
+	return err
+}
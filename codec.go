@@ -0,0 +1,170 @@
+package forge
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+)
+
+// PayloadCodec encodes render payloads into request bodies. JSON is the
+// default; binary encodings such as CBOR or MessagePack can be plugged in
+// with WithCodec once the server accepts them, to cut encoding cost for
+// payloads carrying large base64 images.
+type PayloadCodec interface {
+	// ContentType is the request Content-Type, e.g. "application/cbor".
+	ContentType() string
+	// Marshal encodes a *RenderPayload.
+	Marshal(v any) ([]byte, error)
+}
+
+// JSONCodec encodes payloads as JSON.
+var JSONCodec PayloadCodec = jsonCodec{}
+
+type jsonCodec struct{}
+
+func (jsonCodec) ContentType() string           { return "application/json" }
+func (jsonCodec) Marshal(v any) ([]byte, error) { return json.Marshal(v) }
+
+// WithCodec sets the codec used to encode render payloads (default
+// JSONCodec). Other API calls, and request fingerprints, always use JSON.
+func WithCodec(codec PayloadCodec) Option {
+	return func(c *Client) {
+		c.codec = codec
+	}
+}
+
+// DefaultMultipartThreshold is the total binary size above which render
+// requests are sent as multipart/form-data.
+const DefaultMultipartThreshold = 1 << 20
+
+// WithMultipartThreshold sets the total binary size in bytes above which
+// render requests are sent as multipart/form-data instead of JSON (default
+// DefaultMultipartThreshold). Binary data is assets, including fonts, the
+// source archive, PDF embedded files, and the watermark image. The payload
+// then travels as a JSON part and the binary data as raw parts, avoiding
+// base64's 33% overhead. A negative threshold disables multipart requests.
+// Custom codecs set with WithCodec are never replaced.
+//
+// The encoded body is buffered in memory rather than streamed, so that it
+// can be resent on retry and cached by Frozen.
+func WithMultipartThreshold(threshold int) Option {
+	return func(c *Client) {
+		c.multipartThreshold = threshold
+	}
+}
+
+// encodePayload encodes p as a render request body, returning the body
+// and its Content-Type.
+func (c *Client) encodePayload(p *RenderPayload) ([]byte, string, error) {
+	if c.codec != JSONCodec || c.multipartThreshold < 0 {
+		body, err := c.codec.Marshal(p)
+		return body, c.codec.ContentType(), err
+	}
+	size := 0
+	for _, a := range p.Assets {
+		size += len(a.Data)
+	}
+	if p.Archive != nil {
+		size += len(p.Archive.Data)
+	}
+	if p.Pdf != nil {
+		for _, ef := range p.Pdf.EmbeddedFiles {
+			size += base64.StdEncoding.DecodedLen(len(ef.Data))
+		}
+		if w := p.Pdf.Watermark; w != nil && w.ImageData != nil {
+			size += base64.StdEncoding.DecodedLen(len(*w.ImageData))
+		}
+	}
+	if size <= c.multipartThreshold {
+		body, err := json.Marshal(p)
+		return body, "application/json", err
+	}
+	return encodeMultipart(p)
+}
+
+// binaryPart is a raw multipart request part.
+type binaryPart struct {
+	name, filename, contentType string
+	data                        []byte
+}
+
+// encodeMultipart encodes p as multipart/form-data: a "payload" part holding
+// the JSON payload without its binary data, followed by one "assets" part
+// per asset, named by its filename parameter, an "archive" part holding
+// the zip of an ArchiveSource, one "embedded_files" part per PDF embedded
+// file, named by its path, and a "watermark_image" part. Embedded files and
+// watermark images that are not valid base64 stay in the JSON part, for
+// the server to report.
+func encodeMultipart(p *RenderPayload) ([]byte, string, error) {
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+
+	meta := *p
+	meta.Assets = nil
+	var parts []binaryPart
+	for _, a := range p.Assets {
+		parts = append(parts, binaryPart{"assets", a.Name, a.MimeType, a.Data})
+	}
+	if p.Archive != nil {
+		meta.Archive = &ArchiveSource{Entry: p.Archive.Entry}
+		parts = append(parts, binaryPart{"archive", "archive.zip", "application/zip", p.Archive.Data})
+	}
+	if p.Pdf != nil {
+		pdf := *p.Pdf
+		meta.Pdf = &pdf
+		if len(pdf.EmbeddedFiles) > 0 {
+			pdf.EmbeddedFiles = append([]EmbeddedFile(nil), pdf.EmbeddedFiles...)
+			for i, ef := range pdf.EmbeddedFiles {
+				data, err := base64.StdEncoding.DecodeString(ef.Data)
+				if err != nil {
+					continue
+				}
+				contentType := ef.MimeType
+				if contentType == "" {
+					contentType = "application/octet-stream"
+				}
+				parts = append(parts, binaryPart{"embedded_files", ef.Path, contentType, data})
+				pdf.EmbeddedFiles[i].Data = ""
+			}
+		}
+		if w := pdf.Watermark; w != nil && w.ImageData != nil {
+			if data, err := base64.StdEncoding.DecodeString(*w.ImageData); err == nil {
+				wm := *w
+				wm.ImageData = nil
+				pdf.Watermark = &wm
+				parts = append(parts, binaryPart{"watermark_image", "watermark", http.DetectContentType(data), data})
+			}
+		}
+	}
+	pw, err := mw.CreatePart(textproto.MIMEHeader{
+		"Content-Disposition": {`form-data; name="payload"`},
+		"Content-Type":        {"application/json"},
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	if err := json.NewEncoder(pw).Encode(&meta); err != nil {
+		return nil, "", err
+	}
+
+	for _, part := range parts {
+		aw, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Disposition": {mime.FormatMediaType("form-data", map[string]string{"name": part.name, "filename": part.filename})},
+			"Content-Type":        {part.contentType},
+		})
+		if err != nil {
+			return nil, "", err
+		}
+		if _, err := aw.Write(part.data); err != nil {
+			return nil, "", err
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return nil, "", err
+	}
+	return buf.Bytes(), mw.FormDataContentType(), nil
+}
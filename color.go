@@ -0,0 +1,52 @@
+package forge
+
+import (
+	"bytes"
+	"encoding/base64"
+)
+
+// PdfColorProfile embeds an ICC profile as the PDF's output intent profile,
+// e.g. ISO Coated v2 for European offset printing. With PdfCMYK the
+// document's colors are converted into this profile.
+func (r *RenderRequest) PdfColorProfile(iccData []byte) *RenderRequest {
+	// Every ICC profile carries the "acsp" signature at byte offset 36.
+	if len(iccData) < 40 || !bytes.Equal(iccData[36:40], []byte("acsp")) {
+		r.fail("pdf.color_profile", "not an ICC profile")
+		return r
+	}
+	data := base64.StdEncoding.EncodeToString(iccData)
+	r.pdf().ColorProfile = &data
+	return r
+}
+
+// PdfOutputIntent sets the output condition identifier of the PDF's output
+// intent, e.g. "FOGRA39" or "GRACoL2013", telling the press which printing
+// condition the document was prepared for.
+func (r *RenderRequest) PdfOutputIntent(identifier string) *RenderRequest {
+	if identifier == "" {
+		r.fail("pdf.output_intent", "must not be empty")
+		return r
+	}
+	r.pdf().OutputIntent = &identifier
+	return r
+}
+
+// PdfCMYK converts all colors and images to CMYK using the profile set by
+// PdfColorProfile, or the server's profile for the PdfOutputIntent
+// identifier.
+func (r *RenderRequest) PdfCMYK(enabled bool) *RenderRequest {
+	r.pdf().CMYK = &enabled
+	return r
+}
+
+// validateColor rejects CMYK conversion without a target profile.
+func (r *RenderRequest) validateColor() error {
+	pdf := r.p.Pdf
+	if pdf == nil || pdf.CMYK == nil || !*pdf.CMYK {
+		return nil
+	}
+	if pdf.ColorProfile == nil && pdf.OutputIntent == nil {
+		return &ValidationError{Field: "pdf.cmyk", Message: "requires PdfColorProfile or PdfOutputIntent"}
+	}
+	return nil
+}
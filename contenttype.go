@@ -0,0 +1,80 @@
+package forge
+
+import (
+	"bytes"
+	"mime"
+	"net/http"
+	"slices"
+)
+
+// maxBodyHead is how much of an unexpected response body is kept for
+// UnexpectedContentTypeError.
+const maxBodyHead = 512
+
+// formatSignatures holds the leading bytes of each format's files.
+var formatSignatures = map[OutputFormat][]string{
+	FormatPDF:  {"%PDF"},
+	FormatPNG:  {"\x89PNG"},
+	FormatAPNG: {"\x89PNG"},
+	FormatJPEG: {"\xff\xd8\xff"},
+	FormatGIF:  {"GIF8"},
+	FormatBMP:  {"BM"},
+	FormatQOI:  {"qoif"},
+	FormatTIFF: {"II*\x00", "MM\x00*"},
+	FormatWebP: {"RIFF"},
+}
+
+// formatContentTypes lists the media types accepted for each format.
+var formatContentTypes = map[OutputFormat][]string{
+	FormatPDF:  {"application/pdf"},
+	FormatPNG:  {"image/png"},
+	FormatJPEG: {"image/jpeg"},
+	FormatBMP:  {"image/bmp", "image/x-ms-bmp"},
+	FormatTGA:  {"image/x-tga", "image/x-targa", "image/tga"},
+	FormatQOI:  {"image/qoi", "image/x-qoi"},
+	FormatSVG:  {"image/svg+xml"},
+	FormatWebP: {"image/webp"},
+	FormatTIFF: {"image/tiff"},
+	FormatAVIF: {"image/avif"},
+	FormatGIF:  {"image/gif"},
+	FormatAPNG: {"image/apng", "image/png"},
+	FormatHTML: {"text/html"},
+}
+
+// checkContentType returns an *UnexpectedContentTypeError if a render
+// response's Content-Type contradicts the format requested in p. Responses
+// without a Content-Type or with a generic binary one are accepted, as are
+// bodies that start with the format's file signature despite a wrong
+// Content-Type.
+func checkContentType(p *RenderPayload, h http.Header, data []byte) error {
+	ct := h.Get("Content-Type")
+	if ct == "" {
+		return nil
+	}
+	mediaType, _, err := mime.ParseMediaType(ct)
+	if err == nil && mediaType == "application/octet-stream" {
+		return nil
+	}
+
+	format := p.Format
+	want := formatContentTypes[format]
+	if p.Email != nil && *p.Email {
+		want = []string{"application/zip"}
+	}
+	if want == nil {
+		return nil // FormatAuto or a format this SDK version does not know
+	}
+	if err == nil && slices.Contains(want, mediaType) {
+		return nil
+	}
+	for _, sig := range formatSignatures[format] {
+		if bytes.HasPrefix(data, []byte(sig)) {
+			return nil
+		}
+	}
+	return &UnexpectedContentTypeError{
+		Format:      format,
+		ContentType: ct,
+		Head:        append([]byte(nil), data[:min(len(data), maxBodyHead)]...),
+	}
+}
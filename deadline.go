@@ -0,0 +1,55 @@
+package forge
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// DefaultDeadlineMargin is the share of a context deadline reserved for
+// network transfer when deriving the server-side render budget.
+const DefaultDeadlineMargin = time.Second
+
+// WithDeadlineMargin sets how much of a render's context deadline is
+// reserved for network transfer (default DefaultDeadlineMargin). When the
+// context passed to a Send method has a deadline, the remainder becomes
+// the server's render budget: it is sent in the X-Forge-Timeout header and
+// caps the Timeout field, so the server gives up before the caller does.
+// A negative margin disables deadline propagation.
+func WithDeadlineMargin(d time.Duration) Option {
+	return func(c *Client) {
+		c.deadlineMargin = d
+	}
+}
+
+// renderBudget returns the server-side time available for a render
+// started now under ctx. ok is false if ctx has no deadline or
+// propagation is disabled.
+func (c *Client) renderBudget(ctx context.Context) (budget time.Duration, ok bool) {
+	deadline, ok := ctx.Deadline()
+	if !ok || c.deadlineMargin < 0 {
+		return 0, false
+	}
+	return time.Until(deadline) - c.deadlineMargin, true
+}
+
+// applyBudget returns p with its timeout capped to budget, rounded down to
+// whole seconds but at least one. p is not modified.
+func applyBudget(p *RenderPayload, budget time.Duration) (*RenderPayload, error) {
+	if budget <= 0 {
+		return nil, fmt.Errorf("forge: render budget exhausted: %w", context.DeadlineExceeded)
+	}
+	seconds := max(int(budget/time.Second), 1)
+	if p.Timeout != nil && *p.Timeout <= seconds {
+		return p, nil
+	}
+	c := *p
+	c.Timeout = &seconds
+	return &c, nil
+}
+
+// budgetHeader formats budget for X-Forge-Timeout, in milliseconds.
+func budgetHeader(budget time.Duration) string {
+	return strconv.FormatInt(budget.Milliseconds(), 10)
+}
@@ -0,0 +1,209 @@
+package forge
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"runtime"
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+// maxRecentWarnings is the number of render warnings a client remembers
+// for DebugBundle.
+const maxRecentWarnings = 50
+
+// warningLog is a bounded log of recent render warnings.
+type warningLog struct {
+	mu    sync.Mutex
+	items []string
+}
+
+func (l *warningLog) add(warnings []string) {
+	if len(warnings) == 0 {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.items = append(l.items, warnings...)
+	if n := len(l.items) - maxRecentWarnings; n > 0 {
+		l.items = append([]string(nil), l.items[n:]...)
+	}
+}
+
+func (l *warningLog) recent() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]string(nil), l.items...)
+}
+
+// BuildInfo describes the SDK build and runtime, as recorded in debug
+// bundles.
+type BuildInfo struct {
+	// Version is the SDK Version.
+	Version string `json:"version"`
+	// ModuleVersion is the SDK module version the binary was built with,
+	// e.g. "v0.1.0" or a pseudo-version, if the binary records it.
+	ModuleVersion string `json:"module_version,omitempty"`
+	Go            string `json:"go"`
+	OS            string `json:"os"`
+	Arch          string `json:"arch"`
+}
+
+// modulePath is the SDK's module path.
+const modulePath = "github.com/centrixsystems/forge-sdk-go"
+
+// ReadBuildInfo returns the SDK build and runtime information.
+func ReadBuildInfo() BuildInfo {
+	bi := BuildInfo{Version: Version, Go: runtime.Version(), OS: runtime.GOOS, Arch: runtime.GOARCH}
+	if info, ok := debug.ReadBuildInfo(); ok {
+		mods := append([]*debug.Module{&info.Main}, info.Deps...)
+		for _, m := range mods {
+			if m.Path == modulePath {
+				if m.Replace != nil {
+					m = m.Replace
+				}
+				if m.Version != "(devel)" {
+					bi.ModuleVersion = m.Version
+				}
+				break
+			}
+		}
+	}
+	return bi
+}
+
+type debugBundle struct {
+	GeneratedAt    time.Time      `json:"generated_at"`
+	SDK            BuildInfo      `json:"sdk"`
+	Client         debugClient    `json:"client"`
+	Server         debugServer    `json:"server"`
+	Request        *RenderPayload `json:"request"`
+	Render         debugRender    `json:"render"`
+	RecentWarnings []string       `json:"recent_warnings"`
+}
+
+type debugClient struct {
+	BaseURL           string      `json:"base_url"`
+	Via               string      `json:"via,omitempty"`
+	TimeoutMS         int64       `json:"timeout_ms"`
+	Retry             *debugRetry `json:"retry,omitempty"`
+	CompressThreshold *int        `json:"compress_threshold,omitempty"`
+	ErrorLocale       string      `json:"error_locale,omitempty"`
+	APIVersion        string      `json:"api_version,omitempty"`
+}
+
+type debugRetry struct {
+	MaxAttempts  int     `json:"max_attempts"`
+	BackoffMS    float64 `json:"backoff_ms"`
+	MaxBackoffMS float64 `json:"max_backoff_ms"`
+	RetryOn      []int   `json:"retry_on"`
+}
+
+type debugServer struct {
+	Healthy         bool    `json:"healthy"`
+	HealthError     string  `json:"health_error,omitempty"`
+	HealthLatencyMS float64 `json:"health_latency_ms"`
+	Engine          string  `json:"engine,omitempty"`
+	WorkerID        string  `json:"worker_id,omitempty"`
+}
+
+type debugRender struct {
+	ElapsedMS        float64        `json:"elapsed_ms"`
+	RenderDurationMS float64        `json:"render_duration_ms,omitempty"`
+	CacheStatus      CacheStatus    `json:"cache_status,omitempty"`
+	OutputBytes      int            `json:"output_bytes"`
+	Warnings         []string       `json:"warnings,omitempty"`
+	Attempts         []debugAttempt `json:"attempts,omitempty"`
+	Error            string         `json:"error,omitempty"`
+}
+
+type debugAttempt struct {
+	StatusCode int     `json:"status_code,omitempty"`
+	Error      string  `json:"error,omitempty"`
+	DurationMS float64 `json:"duration_ms"`
+}
+
+// DebugBundle executes req and returns a JSON document for support tickets.
+//
+// The bundle contains the SDK and Go versions, the client configuration,
+// server health and engine version, the request payload with secrets
+// redacted, the render's timing, attempts, and warnings, and the client's
+// recent warnings. A failed render is recorded in the bundle rather than
+// returned as an error. req must have been built from c, so that the
+// bundle describes the client that sends it.
+func (c *Client) DebugBundle(ctx context.Context, req *RenderRequest) ([]byte, error) {
+	if req.client != c {
+		return nil, &ValidationError{Field: "request", Message: "built from a different client"}
+	}
+	b := debugBundle{
+		GeneratedAt: time.Now().UTC(),
+		SDK:         ReadBuildInfo(),
+		Client: debugClient{
+			BaseURL:     c.baseURL,
+			Via:         req.via,
+			TimeoutMS:   c.httpClient.Timeout.Milliseconds(),
+			ErrorLocale: c.locale,
+			APIVersion:  c.apiVersion,
+		},
+		Request: redactPayload(req.Payload()),
+	}
+	if p := c.retry; p != nil {
+		b.Client.Retry = &debugRetry{
+			MaxAttempts:  p.MaxAttempts,
+			BackoffMS:    ms(p.Backoff),
+			MaxBackoffMS: ms(p.MaxBackoff),
+			RetryOn:      p.RetryOn,
+		}
+	}
+	if c.compress {
+		b.Client.CompressThreshold = &c.compressThreshold
+	}
+
+	start := time.Now()
+	healthy, err := c.Health(ctx)
+	b.Server.HealthLatencyMS = ms(time.Since(start))
+	b.Server.Healthy = healthy
+	if err != nil {
+		b.Server.HealthError = err.Error()
+	}
+
+	start = time.Now()
+	res, err := req.SendWithWarnings(ctx)
+	b.Render.ElapsedMS = ms(time.Since(start))
+	var attempts []Attempt
+	if err != nil {
+		b.Render.Error = err.Error()
+		var se *ServerError
+		var ce *ConnectionError
+		switch {
+		case errors.As(err, &se):
+			attempts = se.Attempts
+		case errors.As(err, &ce):
+			attempts = ce.Attempts
+		}
+	} else {
+		attempts = res.Attempts
+		b.Server.Engine = res.Engine
+		b.Server.WorkerID = res.WorkerID
+		b.Render.RenderDurationMS = ms(res.RenderDuration)
+		b.Render.CacheStatus = res.CacheStatus
+		b.Render.OutputBytes = len(res.Data)
+		b.Render.Warnings = res.Warnings
+	}
+	for _, a := range attempts {
+		da := debugAttempt{StatusCode: a.StatusCode, DurationMS: ms(a.Duration)}
+		if a.Err != nil {
+			da.Error = a.Err.Error()
+		}
+		b.Render.Attempts = append(b.Render.Attempts, da)
+	}
+	b.RecentWarnings = c.warnings.recent()
+
+	return json.MarshalIndent(b, "", "  ")
+}
+
+func ms(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,109 @@
+package forge
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDebugBundle(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/health" {
+			return
+		}
+		w.Header().Set("X-Forge-Engine", "forge/2.4.1")
+		w.Header().Add("X-Forge-Warning", "font fallback: Inter")
+		w.Write([]byte("%PDF"))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+	req := c.RenderHTML("<h1>Hi</h1>").
+		PdfSignCertificate("c2VjcmV0LWNlcnQ=").
+		PdfSignPassword("hunter2").
+		PdfUserPassword("user-pw").
+		PdfOwnerPassword("owner-pw")
+	data, err := c.DebugBundle(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"hunter2", "c2VjcmV0LWNlcnQ=", "user-pw", "owner-pw"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("bundle leaks %q", secret)
+		}
+	}
+
+	var b struct {
+		SDK struct {
+			Version string `json:"version"`
+		} `json:"sdk"`
+		Server struct {
+			Healthy bool   `json:"healthy"`
+			Engine  string `json:"engine"`
+		} `json:"server"`
+		Request struct {
+			HTML string `json:"html"`
+		} `json:"request"`
+		Render struct {
+			OutputBytes int `json:"output_bytes"`
+			Attempts    []struct {
+				StatusCode int `json:"status_code"`
+			} `json:"attempts"`
+		} `json:"render"`
+		RecentWarnings []string `json:"recent_warnings"`
+	}
+	if err := json.Unmarshal(data, &b); err != nil {
+		t.Fatal(err)
+	}
+	if b.SDK.Version != Version {
+		t.Errorf("sdk.version = %q, want %q", b.SDK.Version, Version)
+	}
+	if !b.Server.Healthy || b.Server.Engine != "forge/2.4.1" {
+		t.Errorf("server = %+v", b.Server)
+	}
+	if b.Request.HTML != "<h1>Hi</h1>" {
+		t.Errorf("request.html = %q", b.Request.HTML)
+	}
+	if b.Render.OutputBytes != 4 || len(b.Render.Attempts) != 1 || b.Render.Attempts[0].StatusCode != 200 {
+		t.Errorf("render = %+v", b.Render)
+	}
+	if len(b.RecentWarnings) != 1 || b.RecentWarnings[0] != "font fallback: Inter" {
+		t.Errorf("recent_warnings = %v", b.RecentWarnings)
+	}
+}
+
+func TestDebugBundleRecordsFailure(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":"bad html"}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+	data, err := c.DebugBundle(context.Background(), c.RenderHTML("<p>"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "bad html") {
+		t.Errorf("bundle does not record render error: %s", data)
+	}
+}
+
+func TestDebugBundleRejectsForeignRequest(t *testing.T) {
+	c := NewClient("http://localhost:3000")
+	other := NewClient("http://localhost:3001", WithErrorLocale("de"))
+	_, err := c.DebugBundle(context.Background(), other.RenderHTML("<p>"))
+	if ve, ok := err.(*ValidationError); !ok || ve.Field != "request" {
+		t.Errorf("err = %v, want request *ValidationError", err)
+	}
+}
+
+func TestReadBuildInfo(t *testing.T) {
+	bi := ReadBuildInfo()
+	if bi.Version != Version || bi.Go == "" || bi.OS == "" || bi.Arch == "" {
+		t.Errorf("ReadBuildInfo() = %+v", bi)
+	}
+}
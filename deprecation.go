@@ -0,0 +1,159 @@
+package forge
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// Capabilities describes what a Forge server supports.
+type Capabilities struct {
+	// Version is the server version.
+	Version string `json:"version"`
+	// Deprecated lists payload options the server will remove.
+	Deprecated []Deprecation `json:"deprecated,omitempty"`
+}
+
+// Deprecation describes a payload option scheduled for removal.
+type Deprecation struct {
+	// Option is the option's dotted JSON path, e.g. "pdf.mode".
+	Option string `json:"option"`
+	// RemovedIn is the server version that will reject the option.
+	RemovedIn string `json:"removed_in,omitempty"`
+	// Replacement is the option to use instead, if any.
+	Replacement string `json:"replacement,omitempty"`
+	Message     string `json:"message,omitempty"`
+}
+
+func (d Deprecation) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "option %q is deprecated", d.Option)
+	if d.RemovedIn != "" {
+		fmt.Fprintf(&b, " and will be removed in server %s", d.RemovedIn)
+	}
+	if d.Replacement != "" {
+		fmt.Fprintf(&b, "; use %q instead", d.Replacement)
+	}
+	if d.Message != "" {
+		b.WriteString(": " + d.Message)
+	}
+	return b.String()
+}
+
+// WithWarningHandler sets the function that receives SDK warnings, such as
+// uses of deprecated options. By default they go to the standard logger;
+// a nil fn discards them.
+func WithWarningHandler(fn func(msg string)) Option {
+	return func(c *Client) {
+		c.warn = fn
+	}
+}
+
+func defaultWarningHandler(msg string) {
+	log.Print(msg)
+}
+
+// Capabilities fetches the server's capabilities. Deprecated options it
+// reports are remembered by the client: the first render that uses each
+// one triggers a warning through the warning handler, giving callers a
+// migration window before the server starts rejecting it.
+func (c *Client) Capabilities(ctx context.Context) (*Capabilities, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, "/capabilities", nil, "")
+	if err != nil {
+		return nil, fmt.Errorf("forge: request error: %w", err)
+	}
+	data, err := c.call(req)
+	if err != nil {
+		return nil, err
+	}
+	var caps Capabilities
+	if err := json.Unmarshal(data, &caps); err != nil {
+		return nil, fmt.Errorf("forge: decode capabilities: %w", err)
+	}
+	c.deprecations.set(caps.Deprecated)
+	return &caps, nil
+}
+
+// deprecationRegistry holds the deprecated options reported by the server
+// and which of them have already been warned about.
+type deprecationRegistry struct {
+	mu     sync.Mutex
+	byPath map[string]Deprecation
+	warned map[string]bool
+}
+
+func (d *deprecationRegistry) set(deps []Deprecation) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.byPath = make(map[string]Deprecation, len(deps))
+	if d.warned == nil {
+		d.warned = make(map[string]bool)
+	}
+	for _, dep := range deps {
+		d.byPath[dep.Option] = dep
+	}
+}
+
+// check returns the deprecations p uses that have not been reported yet,
+// marking them reported.
+func (d *deprecationRegistry) check(p *RenderPayload) []Deprecation {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	pending := false
+	for path := range d.byPath {
+		if !d.warned[path] {
+			pending = true
+			break
+		}
+	}
+	if !pending {
+		return nil
+	}
+	data, err := json.Marshal(p)
+	if err != nil {
+		return nil
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil
+	}
+
+	var used []Deprecation
+	for path, dep := range d.byPath {
+		if !d.warned[path] && hasPath(doc, path) {
+			d.warned[path] = true
+			used = append(used, dep)
+		}
+	}
+	return used
+}
+
+// hasPath reports whether the dotted path exists in doc.
+func hasPath(doc map[string]any, path string) bool {
+	var v any = doc
+	for _, key := range strings.Split(path, ".") {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return false
+		}
+		if v, ok = m[key]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// warnDeprecated reports deprecated options used by p, once per option.
+func (c *Client) warnDeprecated(p *RenderPayload) {
+	for _, dep := range c.deprecations.check(p) {
+		msg := "forge: " + dep.String()
+		c.warnings.add([]string{msg})
+		if c.warn != nil {
+			c.warn(msg)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package forge
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDeprecationWarnings(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/capabilities" {
+			w.Write([]byte(`{"version":"2.9.0","deprecated":[
+				{"option":"pdf.mode","removed_in":"3.0","replacement":"engine"},
+				{"option":"density"}]}`))
+			return
+		}
+		w.Write([]byte("%PDF"))
+	}))
+	defer srv.Close()
+
+	var got []string
+	c := NewClient(srv.URL, WithWarningHandler(func(msg string) { got = append(got, msg) }))
+
+	// Nothing is known before capabilities are fetched.
+	if _, err := c.RenderHTML("x").PdfMode(PdfModeVector).Send(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 0 {
+		t.Fatalf("warnings before Capabilities: %v", got)
+	}
+
+	caps, err := c.Capabilities(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if caps.Version != "2.9.0" || len(caps.Deprecated) != 2 {
+		t.Errorf("caps = %+v", caps)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.RenderHTML("x").PdfMode(PdfModeVector).Send(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := c.RenderHTML("x").PdfTitle("t").Send(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	want := `forge: option "pdf.mode" is deprecated and will be removed in server 3.0; use "engine" instead`
+	if len(got) != 1 || got[0] != want {
+		t.Errorf("warnings = %q, want [%q]", got, want)
+	}
+
+	if _, err := c.RenderURL("https://example.com").Density(2).Send(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || !strings.Contains(got[1], `"density"`) {
+		t.Errorf("warnings = %q", got)
+	}
+}
@@ -0,0 +1,180 @@
+package forge
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// partKind is the role of a part in an assembled document.
+type partKind int
+
+const (
+	partCover partKind = iota
+	partSection
+	partAppendix
+)
+
+type documentPart struct {
+	kind  partKind
+	title string
+	req   *RenderRequest
+}
+
+// DocumentBuilder assembles a multi-part PDF, such as a board pack, from a
+// cover, a table of contents, sections, and appendices. Each part is an
+// ordinary RenderRequest with its own source and options; Build renders
+// the parts concurrently and merges them with Pdf().Merge.
+type DocumentBuilder struct {
+	client   *Client
+	parts    []documentPart
+	toc      *MergeTOC
+	err      error
+	hasCover bool
+}
+
+// Document starts a document assembly.
+func (c *Client) Document() *DocumentBuilder {
+	return &DocumentBuilder{client: c}
+}
+
+// Cover sets the cover page, placed first and left out of the table of
+// contents.
+func (d *DocumentBuilder) Cover(req *RenderRequest) *DocumentBuilder {
+	if req == nil {
+		d.fail("cover", "nil request")
+		return d
+	}
+	if d.hasCover {
+		d.fail("cover", "already set")
+		return d
+	}
+	d.hasCover = true
+	d.parts = append([]documentPart{{kind: partCover, req: req}}, d.parts...)
+	return d
+}
+
+// TableOfContents inserts a generated table of contents after the cover,
+// listing every section and appendix with its page number.
+func (d *DocumentBuilder) TableOfContents(title string) *DocumentBuilder {
+	d.toc = &MergeTOC{Title: title}
+	return d
+}
+
+// Section appends a section, bookmarked and listed under title.
+func (d *DocumentBuilder) Section(title string, req *RenderRequest) *DocumentBuilder {
+	if req == nil {
+		d.fail("section", fmt.Sprintf("nil request for %q", title))
+		return d
+	}
+	d.parts = append(d.parts, documentPart{kind: partSection, title: title, req: req})
+	return d
+}
+
+// Appendix appends an appendix. Appendices are lettered in order, so the
+// first is listed as "Appendix A: title". Sections added after an
+// appendix are rejected.
+func (d *DocumentBuilder) Appendix(title string, req *RenderRequest) *DocumentBuilder {
+	if req == nil {
+		d.fail("appendix", fmt.Sprintf("nil request for %q", title))
+		return d
+	}
+	d.parts = append(d.parts, documentPart{kind: partAppendix, title: title, req: req})
+	return d
+}
+
+func (d *DocumentBuilder) fail(field, msg string) {
+	if d.err == nil {
+		d.err = &ValidationError{Field: "document." + field, Message: msg}
+	}
+}
+
+// validate checks the document structure and that every part renders to PDF.
+func (d *DocumentBuilder) validate() error {
+	if d.err != nil {
+		return d.err
+	}
+	if len(d.parts) == 0 {
+		return &ValidationError{Field: "document", Message: "no parts"}
+	}
+	inAppendix := false
+	for i, part := range d.parts {
+		switch part.kind {
+		case partAppendix:
+			inAppendix = true
+		case partSection:
+			if inAppendix {
+				return &ValidationError{Field: "document", Message: fmt.Sprintf("section %q follows an appendix", part.title)}
+			}
+		}
+		if f := part.req.p.Format; f != "" && f != FormatPDF {
+			return &ValidationError{Field: "document", Message: fmt.Sprintf("part %d renders to %s, not PDF", i, f)}
+		}
+	}
+	return nil
+}
+
+// Build renders every part and merges them into one PDF. The first part
+// error is returned.
+func (d *DocumentBuilder) Build(ctx context.Context) ([]byte, error) {
+	if err := d.validate(); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	docs := make([]MergeDocument, len(d.parts))
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+	appendix := 0
+	for i, part := range d.parts {
+		switch part.kind {
+		case partSection:
+			docs[i].Bookmark = part.title
+		case partAppendix:
+			docs[i].Bookmark = fmt.Sprintf("Appendix %s: %s", appendixLabel(appendix), part.title)
+			appendix++
+		}
+		wg.Add(1)
+		go func(i int, req *RenderRequest) {
+			defer wg.Done()
+			data, err := req.Send(ctx)
+			if err != nil {
+				once.Do(func() {
+					firstErr = fmt.Errorf("forge: document part %d: %w", i, err)
+					cancel()
+				})
+				return
+			}
+			docs[i].Data = data
+		}(i, part.req)
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	merge := MergeRequest{Documents: docs}
+	if d.toc != nil {
+		toc := *d.toc
+		if d.hasCover {
+			toc.Position = 1
+		}
+		merge.TOC = &toc
+	}
+	return d.client.Pdf().Merge(ctx, merge)
+}
+
+// appendixLabel returns the letters of the nth appendix, counting from 0:
+// A through Z, then AA, AB, and so on.
+func appendixLabel(n int) string {
+	var label []byte
+	for n++; n > 0; n = (n - 1) / 26 {
+		label = append([]byte{byte('A' + (n-1)%26)}, label...)
+	}
+	return string(label)
+}
@@ -0,0 +1,92 @@
+package forge
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDocumentBuilder(t *testing.T) {
+	var merged MergeRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/render":
+			var p RenderPayload
+			json.NewDecoder(r.Body).Decode(&p)
+			w.Write([]byte("%PDF " + *p.HTML))
+		case "/pdf/merge":
+			json.NewDecoder(r.Body).Decode(&merged)
+			w.Write([]byte("%PDF merged"))
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+	pdf, err := c.Document().
+		Section("Finance", c.RenderHTML("finance")).
+		Appendix("Minutes", c.RenderHTML("minutes")).
+		Cover(c.RenderHTML("cover")).
+		TableOfContents("Contents").
+		Build(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(pdf) != "%PDF merged" {
+		t.Errorf("pdf = %q", pdf)
+	}
+
+	want := []MergeDocument{
+		{Data: []byte("%PDF cover")},
+		{Data: []byte("%PDF finance"), Bookmark: "Finance"},
+		{Data: []byte("%PDF minutes"), Bookmark: "Appendix A: Minutes"},
+	}
+	if len(merged.Documents) != len(want) {
+		t.Fatalf("documents = %d, want %d", len(merged.Documents), len(want))
+	}
+	for i, d := range merged.Documents {
+		if string(d.Data) != string(want[i].Data) || d.Bookmark != want[i].Bookmark {
+			t.Errorf("documents[%d] = {%q %q}, want {%q %q}", i, d.Data, d.Bookmark, want[i].Data, want[i].Bookmark)
+		}
+	}
+	if merged.TOC == nil || merged.TOC.Title != "Contents" || merged.TOC.Position != 1 {
+		t.Errorf("toc = %+v", merged.TOC)
+	}
+}
+
+func TestDocumentBuilderErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+	_, err := c.Document().Section("A", c.RenderHTML("a")).Build(context.Background())
+	var se *ServerError
+	if !errors.As(err, &se) {
+		t.Errorf("err = %v, want *ServerError", err)
+	}
+
+	for _, d := range []*DocumentBuilder{
+		c.Document(),
+		c.Document().Appendix("A", c.RenderHTML("a")).Section("B", c.RenderHTML("b")),
+		c.Document().Section("A", c.RenderHTML("a").Format(FormatPNG)),
+		c.Document().Section("A", nil),
+		c.Document().Appendix("A", nil),
+		c.Document().Cover(nil).Section("A", c.RenderHTML("a")),
+	} {
+		if _, err := d.Build(context.Background()); !errors.As(err, new(*ValidationError)) {
+			t.Errorf("err = %v, want *ValidationError", err)
+		}
+	}
+}
+
+func TestAppendixLabel(t *testing.T) {
+	for n, want := range map[int]string{0: "A", 25: "Z", 26: "AA", 27: "AB", 51: "AZ", 52: "BA", 701: "ZZ", 702: "AAA"} {
+		if got := appendixLabel(n); got != want {
+			t.Errorf("appendixLabel(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package forge
+
+// DraftWatermark is the watermark text DraftMode stamps when the request
+// does not configure its own watermark.
+const DraftWatermark = "DRAFT"
+
+// DraftMode marks the render as a preview: every page is stamped with a
+// watermark and signing and encryption are rejected, so a draft can never
+// pass for a final document. The watermark defaults to DraftWatermark and
+// can be customized with the PdfWatermark options. PDF output only.
+func (r *RenderRequest) DraftMode(enabled bool) *RenderRequest {
+	r.draft = enabled
+	return r
+}
+
+// applyDraft adds the default draft watermark to p, a copy of the request
+// payload, copying nested options rather than modifying the request's own.
+func (r *RenderRequest) applyDraft(p *RenderPayload) {
+	if !r.draft {
+		return
+	}
+	pdf := PdfOptions{}
+	if p.Pdf != nil {
+		pdf = *p.Pdf
+	}
+	wm := WatermarkOptions{}
+	if pdf.Watermark != nil {
+		wm = *pdf.Watermark
+	}
+	if wm.Text == nil && wm.ImageData == nil {
+		text := DraftWatermark
+		wm.Text = &text
+	}
+	pdf.Watermark = &wm
+	p.Pdf = &pdf
+}
+
+// validateDraft rejects draft renders that could be mistaken for final
+// documents: signed, encrypted, partially watermarked, or not PDF.
+func (r *RenderRequest) validateDraft() error {
+	if !r.draft {
+		return nil
+	}
+	if r.p.Format != "" && r.p.Format != FormatPDF {
+		return &ValidationError{Field: "draft", Message: "applies only to PDF output"}
+	}
+	pdf := r.p.Pdf
+	if pdf == nil {
+		return nil
+	}
+	if pdf.Signature != nil {
+		return &ValidationError{Field: "draft", Message: "draft documents cannot be signed"}
+	}
+	if pdf.Encryption != nil {
+		return &ValidationError{Field: "draft", Message: "draft documents cannot be encrypted"}
+	}
+	if pdf.Watermark != nil && pdf.Watermark.Pages != nil {
+		return &ValidationError{Field: "draft", Message: "the draft watermark must cover all pages"}
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package forge
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"path"
+	"sort"
+	"strings"
+)
+
+// EmailMode renders email-safe HTML instead of a document: CSS is inlined
+// into style attributes and images are extracted and referenced as
+// "cid:<content-id>". The server returns a zip bundle; use SendEmail to
+// receive it parsed. Enabling it sets the format to FormatHTML.
+func (r *RenderRequest) EmailMode(enabled bool) *RenderRequest {
+	if enabled {
+		r.p.Format = FormatHTML
+		r.p.Email = &enabled
+	} else {
+		r.p.Email = nil
+	}
+	return r
+}
+
+// EmailBundle is the output of an EmailMode render.
+type EmailBundle struct {
+	// HTML is the email body with inlined CSS.
+	HTML string
+	// Images are the images the HTML references as "cid:<ContentID>",
+	// sorted by ContentID.
+	Images []EmailImage
+}
+
+// EmailImage is an image referenced by an email body.
+type EmailImage struct {
+	ContentID   string
+	ContentType string
+	Data        []byte
+}
+
+// SendEmail executes an EmailMode render and returns the parsed bundle.
+func (r *RenderRequest) SendEmail(ctx context.Context) (*EmailBundle, error) {
+	if r.p.Email == nil {
+		return nil, &ValidationError{Field: "email", Message: "SendEmail requires EmailMode(true)"}
+	}
+	data, err := r.Send(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return ParseEmailBundle(data)
+}
+
+// ParseEmailBundle parses the zip bundle returned by an EmailMode render:
+// index.html plus an images/ directory named by content ID.
+func ParseEmailBundle(data []byte) (*EmailBundle, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("forge: email bundle: %w", err)
+	}
+	b := &EmailBundle{}
+	foundHTML := false
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		content, err := readZipFile(f)
+		if err != nil {
+			return nil, fmt.Errorf("forge: email bundle: %s: %w", f.Name, err)
+		}
+		switch {
+		case f.Name == "index.html":
+			b.HTML = string(content)
+			foundHTML = true
+		case strings.HasPrefix(f.Name, "images/"):
+			name := strings.TrimPrefix(f.Name, "images/")
+			ct := mime.TypeByExtension(path.Ext(name))
+			if ct == "" {
+				ct = "application/octet-stream"
+			}
+			b.Images = append(b.Images, EmailImage{ContentID: name, ContentType: ct, Data: content})
+		}
+	}
+	if !foundHTML {
+		return nil, fmt.Errorf("forge: email bundle: missing index.html")
+	}
+	sort.Slice(b.Images, func(i, j int) bool { return b.Images[i].ContentID < b.Images[j].ContentID })
+	return b, nil
+}
+
+func readZipFile(f *zip.File) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
+
+// validateEmail rejects EmailMode combined with a non-HTML format.
+func (r *RenderRequest) validateEmail() error {
+	if r.p.Email != nil && r.p.Format != FormatHTML {
+		return &ValidationError{Field: "email", Message: fmt.Sprintf("email mode renders HTML, not %s", r.p.Format)}
+	}
+	return nil
+}
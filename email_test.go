@@ -0,0 +1,61 @@
+package forge
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSendEmail(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var p map[string]any
+		json.NewDecoder(r.Body).Decode(&p)
+		if p["format"] != "html" || p["email"] != true {
+			t.Errorf("payload = %v", p)
+		}
+		zw := zip.NewWriter(w)
+		for name, content := range map[string]string{
+			"index.html":      `<p style="color:red"><img src="cid:logo.png"></p>`,
+			"images/logo.png": "\x89PNG",
+		} {
+			f, _ := zw.Create(name)
+			f.Write([]byte(content))
+		}
+		zw.Close()
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+	b, err := c.RenderHTML("<style>p{color:red}</style><p><img src=logo.png></p>").EmailMode(true).SendEmail(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.HTML != `<p style="color:red"><img src="cid:logo.png"></p>` {
+		t.Errorf("HTML = %q", b.HTML)
+	}
+	if len(b.Images) != 1 || b.Images[0].ContentID != "logo.png" || b.Images[0].ContentType != "image/png" {
+		t.Errorf("Images = %+v", b.Images)
+	}
+}
+
+func TestEmailModeValidation(t *testing.T) {
+	c := NewClient("http://localhost:3000")
+	_, err := c.RenderHTML("x").EmailMode(true).Format(FormatPDF).Send(context.Background())
+	if ve, ok := err.(*ValidationError); !ok || ve.Field != "email" {
+		t.Errorf("err = %v, want email *ValidationError", err)
+	}
+	_, err = c.RenderHTML("x").SendEmail(context.Background())
+	if ve, ok := err.(*ValidationError); !ok || ve.Field != "email" {
+		t.Errorf("err = %v, want email *ValidationError", err)
+	}
+
+	var buf bytes.Buffer
+	zip.NewWriter(&buf).Close()
+	if _, err := ParseEmailBundle(buf.Bytes()); err == nil {
+		t.Error("bundle without index.html accepted")
+	}
+}
@@ -1,20 +1,82 @@
 package forge
 
-import "fmt"
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
 
 // ServerError is returned when the server responds with a 4xx/5xx status.
 type ServerError struct {
 	StatusCode int
 	Message    string
+	// Code is the server's stable, English error code (e.g. "invalid_paper"),
+	// unaffected by WithErrorLocale. Empty if the server did not send one.
+	Code string
+	// Attempts lists every HTTP attempt made, including retries.
+	Attempts []Attempt
+	// Elapsed is the total time across all attempts and backoff delays.
+	Elapsed time.Duration
 }
 
 func (e *ServerError) Error() string {
 	return fmt.Sprintf("forge: server error (%d): %s", e.StatusCode, e.Message)
 }
 
+// newServerError builds a ServerError from a non-2xx response body,
+// preferring the server's JSON error message when present.
+func newServerError(status int, body []byte) *ServerError {
+	var errResp struct {
+		Error string `json:"error"`
+		Code  string `json:"code"`
+	}
+	msg := fmt.Sprintf("HTTP %d", status)
+	if json.Unmarshal(body, &errResp) == nil && errResp.Error != "" {
+		msg = errResp.Error
+	}
+	return &ServerError{
+		StatusCode: status,
+		Message:    msg,
+		Code:       errResp.Code,
+	}
+}
+
+// ValidationError is returned by Send when the request is rejected
+// client-side, before anything is sent to the server.
+type ValidationError struct {
+	// Field is the payload field at fault, e.g. "margins".
+	Field   string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("forge: invalid %s: %s", e.Field, e.Message)
+}
+
+// UnexpectedContentTypeError is returned when a successful render response
+// is not of the requested format, such as an HTML error page from a proxy
+// in place of a PDF. The output is withheld so it cannot be mistaken for
+// the document.
+type UnexpectedContentTypeError struct {
+	// Format is the requested output format.
+	Format OutputFormat
+	// ContentType is the response Content-Type.
+	ContentType string
+	// Head is the start of the response body, at most 512 bytes.
+	Head []byte
+}
+
+func (e *UnexpectedContentTypeError) Error() string {
+	return fmt.Sprintf("forge: requested %s output but got Content-Type %q", e.Format, e.ContentType)
+}
+
 // ConnectionError is returned when the HTTP request fails.
 type ConnectionError struct {
 	Cause error
+	// Attempts lists every HTTP attempt made, including retries.
+	Attempts []Attempt
+	// Elapsed is the total time across all attempts and backoff delays.
+	Elapsed time.Duration
 }
 
 func (e *ConnectionError) Error() string {
@@ -24,3 +86,14 @@ func (e *ConnectionError) Error() string {
 func (e *ConnectionError) Unwrap() error {
 	return e.Cause
 }
+
+// FingerprintMismatchError is returned by Commit when the request differs
+// from the one that was approved. Nothing is sent to the server.
+type FingerprintMismatchError struct {
+	Approved string
+	Actual   string
+}
+
+func (e *FingerprintMismatchError) Error() string {
+	return fmt.Sprintf("forge: request fingerprint %s does not match approved %s", e.Actual, e.Approved)
+}
@@ -0,0 +1,130 @@
+package forge
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// EventType identifies a stage in a render job's lifecycle.
+type EventType string
+
+const (
+	EventQueued    EventType = "queued"
+	EventStarted   EventType = "started"
+	EventCompleted EventType = "completed"
+	EventFailed    EventType = "failed"
+)
+
+// Event is a render job notification from the server's event feed.
+type Event struct {
+	// Type is the lifecycle stage the job reached.
+	Type EventType `json:"type"`
+	// JobID identifies the render job.
+	JobID string `json:"job_id"`
+	// Source is the client-reported origin of the job, if any.
+	Source string `json:"source,omitempty"`
+	// Time is when the server emitted the event.
+	Time time.Time `json:"time"`
+	// Error is the failure reason for EventFailed events.
+	Error string `json:"error,omitempty"`
+}
+
+// EventFilter restricts which events the server delivers.
+// The zero value subscribes to all events.
+type EventFilter struct {
+	// Types limits delivery to the given event types.
+	Types []EventType
+	// Source limits delivery to jobs submitted by the given source.
+	Source string
+}
+
+// Events subscribes to the server's render event feed.
+//
+// The returned channel receives events until ctx is cancelled or the server
+// closes the stream, after which it is closed. Malformed events are skipped.
+func (c *Client) Events(ctx context.Context, filter EventFilter) (<-chan Event, error) {
+	q := url.Values{}
+	for _, t := range filter.Types {
+		q.Add("type", string(t))
+	}
+	if filter.Source != "" {
+		q.Set("source", filter.Source)
+	}
+	path := "/events"
+	if len(q) > 0 {
+		path += "?" + q.Encode()
+	}
+
+	req, err := c.newRequest(ctx, http.MethodGet, path, nil, "")
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+
+	// The feed is long-lived, so the client-wide request timeout must not apply.
+	hc := *c.httpClient
+	hc.Timeout = 0
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, &ConnectionError{Cause: err}
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		data, _ := io.ReadAll(resp.Body)
+		return nil, newServerError(resp.StatusCode, data)
+	}
+
+	ch := make(chan Event)
+	go func() {
+		defer close(ch)
+		defer resp.Body.Close()
+		readEvents(ctx, resp.Body, ch)
+	}()
+	return ch, nil
+}
+
+// readEvents parses a text/event-stream body and delivers each event on ch.
+func readEvents(ctx context.Context, body io.Reader, ch chan<- Event) {
+	sc := bufio.NewScanner(body)
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var name string
+	var data strings.Builder
+	for sc.Scan() {
+		line := sc.Text()
+		switch {
+		case line == "":
+			if data.Len() > 0 {
+				var ev Event
+				if json.Unmarshal([]byte(data.String()), &ev) == nil {
+					if ev.Type == "" {
+						ev.Type = EventType(name)
+					}
+					select {
+					case ch <- ev:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+			name = ""
+			data.Reset()
+		case strings.HasPrefix(line, ":"):
+			// Comment or keep-alive.
+		case strings.HasPrefix(line, "event:"):
+			name = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package forge
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestEventsStream(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/events" {
+			t.Errorf("path = %v", r.URL.Path)
+		}
+		if got := r.URL.Query()["type"]; len(got) != 2 || got[0] != "completed" || got[1] != "failed" {
+			t.Errorf("type query = %v", got)
+		}
+		if r.URL.Query().Get("source") != "billing" {
+			t.Errorf("source query = %v", r.URL.Query().Get("source"))
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, ": keep-alive\n\n")
+		fmt.Fprint(w, "event: completed\ndata: {\"job_id\":\"a1\"}\n\n")
+		fmt.Fprint(w, "data: not json\n\n")
+		fmt.Fprint(w, "data: {\"type\":\"failed\",\"job_id\":\"b2\",\"error\":\"timeout\"}\n\n")
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+	ch, err := c.Events(context.Background(), EventFilter{
+		Types:  []EventType{EventCompleted, EventFailed},
+		Source: "billing",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []Event
+	for ev := range ch {
+		got = append(got, ev)
+	}
+	if len(got) != 2 {
+		t.Fatalf("events = %d, want 2", len(got))
+	}
+	if got[0].Type != EventCompleted || got[0].JobID != "a1" {
+		t.Errorf("first event = %+v", got[0])
+	}
+	if got[1].Type != EventFailed || got[1].Error != "timeout" {
+		t.Errorf("second event = %+v", got[1])
+	}
+}
+
+func TestEventsServerError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"error":"events disabled"}`)
+	}))
+	defer srv.Close()
+
+	_, err := NewClient(srv.URL).Events(context.Background(), EventFilter{})
+	se, ok := err.(*ServerError)
+	if !ok {
+		t.Fatalf("err = %T, want *ServerError", err)
+	}
+	if se.StatusCode != 404 || se.Message != "events disabled" {
+		t.Errorf("err = %v", se)
+	}
+}
@@ -0,0 +1,256 @@
+package forge
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// PageCookie is a cookie the server's browser sends when fetching the
+// RenderURL target and its resources.
+type PageCookie struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+	// Domain defaults to the target URL's host.
+	Domain   string `json:"domain,omitempty"`
+	Path     string `json:"path,omitempty"`
+	Secure   bool   `json:"secure,omitempty"`
+	HTTPOnly bool   `json:"http_only,omitempty"`
+	// SameSite is "strict", "lax", or "none".
+	SameSite string `json:"same_site,omitempty"`
+	// Expires is a Unix timestamp; zero means a session cookie.
+	Expires int64 `json:"expires,omitempty"`
+}
+
+// Cookie adds a cookie to the browser session that fetches the RenderURL
+// target, e.g. to render pages behind a login. Options set the remaining
+// PageCookie fields:
+//
+//	req.Cookie("session", token, func(c *forge.PageCookie) { c.Path = "/app" })
+//
+// Cookie values are redacted from debug bundles and journals.
+func (r *RenderRequest) Cookie(name, value string, opts ...func(*PageCookie)) *RenderRequest {
+	c := PageCookie{Name: name, Value: value}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return r.addCookie(c)
+}
+
+// Cookies adds cookies, such as those of an http.CookieJar, to the browser
+// session that fetches the RenderURL target.
+func (r *RenderRequest) Cookies(cookies []*http.Cookie) *RenderRequest {
+	for _, hc := range cookies {
+		c := PageCookie{
+			Name:     hc.Name,
+			Value:    hc.Value,
+			Domain:   hc.Domain,
+			Path:     hc.Path,
+			Secure:   hc.Secure,
+			HTTPOnly: hc.HttpOnly,
+		}
+		switch hc.SameSite {
+		case http.SameSiteStrictMode:
+			c.SameSite = "strict"
+		case http.SameSiteLaxMode:
+			c.SameSite = "lax"
+		case http.SameSiteNoneMode:
+			c.SameSite = "none"
+		}
+		if !hc.Expires.IsZero() {
+			c.Expires = hc.Expires.Unix()
+		}
+		r.addCookie(c)
+	}
+	return r
+}
+
+func (r *RenderRequest) addCookie(c PageCookie) *RenderRequest {
+	if c.Name == "" || strings.ContainsAny(c.Name, "=;, \t\r\n") {
+		r.fail("cookies", "invalid cookie name %q", c.Name)
+		return r
+	}
+	switch c.SameSite {
+	case "", "strict", "lax", "none":
+	default:
+		r.fail("cookies", "cookie %s: unknown SameSite %q", c.Name, c.SameSite)
+		return r
+	}
+	r.p.Cookies = append(r.p.Cookies, c)
+	return r
+}
+
+// PageHeader sets an extra HTTP header, such as Authorization or
+// X-Tenant, that the server's browser sends when fetching the RenderURL
+// target. Setting a header again replaces its value. Use Cookie for
+// cookies. Credential-like header values are redacted from debug bundles
+// and journals.
+func (r *RenderRequest) PageHeader(key, value string) *RenderRequest {
+	if !validHeaderName(key) {
+		r.fail("page_headers", "invalid header name %q", key)
+		return r
+	}
+	key = textproto.CanonicalMIMEHeaderKey(key)
+	switch key {
+	case "Cookie":
+		r.fail("page_headers", "use Cookie to set cookies")
+		return r
+	case "Host", "Content-Length", "Connection", "Transfer-Encoding":
+		r.fail("page_headers", "%s is set by the browser", key)
+		return r
+	}
+	if strings.ContainsAny(value, "\r\n") {
+		r.fail("page_headers", "%s value contains a line break", key)
+		return r
+	}
+	if r.p.PageHeaders == nil {
+		r.p.PageHeaders = make(map[string]string)
+	}
+	r.p.PageHeaders[key] = value
+	return r
+}
+
+// PageBasicAuth sends HTTP basic authentication credentials when fetching
+// the RenderURL target, so sites behind basic auth can be rendered without
+// credentials in the URL. It sets the Authorization page header.
+func (r *RenderRequest) PageBasicAuth(user, pass string) *RenderRequest {
+	if strings.Contains(user, ":") {
+		r.fail("page_headers", "basic auth user must not contain ':'")
+		return r
+	}
+	token := base64.StdEncoding.EncodeToString([]byte(user + ":" + pass))
+	return r.PageHeader("Authorization", "Basic "+token)
+}
+
+// PageUserAgent overrides the rendering browser's User-Agent, both in its
+// requests and as navigator.userAgent, for sites that vary content by
+// browser or turn away headless ones.
+func (r *RenderRequest) PageUserAgent(ua string) *RenderRequest {
+	if strings.TrimSpace(ua) == "" || strings.ContainsAny(ua, "\r\n") {
+		r.fail("page_user_agent", "invalid user agent %q", ua)
+		return r
+	}
+	r.p.PageUserAgent = &ua
+	return r
+}
+
+// PageProxy routes the rendering browser's fetches of the target URL and
+// all sub-resources through a proxy, e.g. "http://proxy.corp:3128" or
+// "socks5://10.0.0.5:1080", to reach intranet content. Proxy credentials
+// in the URL are redacted from debug bundles and journals.
+func (r *RenderRequest) PageProxy(proxyURL string) *RenderRequest {
+	u, err := url.Parse(proxyURL)
+	if err != nil || u.Host == "" {
+		r.fail("page_proxy", "invalid proxy URL %q", proxyURL)
+		return r
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5":
+	default:
+		r.fail("page_proxy", "unsupported proxy scheme %q", u.Scheme)
+		return r
+	}
+	r.p.PageProxy = &proxyURL
+	return r
+}
+
+// ResourceType is a kind of sub-resource the rendering browser fetches.
+type ResourceType string
+
+const (
+	ResourceImage      ResourceType = "image"
+	ResourceFont       ResourceType = "font"
+	ResourceStylesheet ResourceType = "stylesheet"
+	ResourceScript     ResourceType = "script"
+	ResourceMedia      ResourceType = "media"
+	// ResourceXHR covers XMLHttpRequest and fetch calls made by scripts.
+	ResourceXHR ResourceType = "xhr"
+)
+
+// BlockOptions lists sub-resource requests the rendering browser skips.
+type BlockOptions struct {
+	Resources   []ResourceType `json:"resources,omitempty"`
+	URLPatterns []string       `json:"url_patterns,omitempty"`
+}
+
+func (r *RenderRequest) block() *BlockOptions {
+	if r.p.Block == nil {
+		r.p.Block = &BlockOptions{}
+	}
+	return r.p.Block
+}
+
+// BlockResources skips fetching sub-resources of the given types, e.g.
+// ResourceImage for text-only output. The document itself is always
+// fetched.
+func (r *RenderRequest) BlockResources(types ...ResourceType) *RenderRequest {
+	b := r.block()
+	for _, t := range types {
+		if !slices.Contains(b.Resources, t) {
+			b.Resources = append(b.Resources, t)
+		}
+	}
+	return r
+}
+
+// BlockURLPatterns skips sub-resource requests whose URL matches any of the
+// patterns, e.g. "*://*.doubleclick.net/*" for ads or analytics, making
+// output deterministic and faster. In a pattern, * matches any run of
+// characters.
+func (r *RenderRequest) BlockURLPatterns(patterns []string) *RenderRequest {
+	for _, p := range patterns {
+		if p == "" || strings.ContainsAny(p, " \t\r\n") {
+			r.fail("block.url_patterns", "invalid pattern %q", p)
+			return r
+		}
+	}
+	b := r.block()
+	b.URLPatterns = append(b.URLPatterns, patterns...)
+	return r
+}
+
+// validHeaderName reports whether s is an RFC 9110 field name.
+func validHeaderName(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		alnum := c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+		if !alnum && !strings.ContainsRune("!#$%&'*+-.^_`|~", c) {
+			return false
+		}
+	}
+	return true
+}
+
+// isCredentialHeader reports whether a page header likely carries a
+// credential, so that its value must be redacted.
+func isCredentialHeader(key string) bool {
+	key = strings.ToLower(key)
+	for _, s := range []string{"auth", "token", "secret", "key", "session", "password"} {
+		if strings.Contains(key, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateFetch rejects target fetch options on requests without a URL.
+func (r *RenderRequest) validateFetch() error {
+	if r.p.URL != nil {
+		return nil
+	}
+	if len(r.p.Cookies) > 0 {
+		return &ValidationError{Field: "cookies", Message: "apply only to RenderURL"}
+	}
+	if len(r.p.PageHeaders) > 0 {
+		return &ValidationError{Field: "page_headers", Message: "apply only to RenderURL"}
+	}
+	if r.p.Login != nil {
+		return &ValidationError{Field: "login", Message: "applies only to RenderURL"}
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package forge
+
+import (
+	"io/fs"
+	"mime"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// RenderFile starts a render request for a local HTML file and attaches
+// the local files it references, such as images, stylesheets, scripts, and
+// fonts, as assets, so the document renders as it appears when opened in a
+// browser. Stylesheets are scanned for url() and @import references too.
+// Remote URLs are left to the server to fetch. A reference to a missing
+// file, or to one outside the HTML file's directory, is reported by the
+// request's Send methods.
+func (c *Client) RenderFile(name string) *RenderRequest {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		r := c.RenderHTML("")
+		r.fail("file", "%v", err)
+		return r
+	}
+	r := c.RenderHTML(string(data))
+	b := bundler{r: r, root: os.DirFS(filepath.Dir(name)), seen: map[string]bool{}}
+	a := newAnalyzer()
+	if err := a.scan(string(data)); err != nil {
+		r.fail("file", "%s: %v", name, strings.TrimPrefix(err.Error(), "forge: "))
+		return r
+	}
+	b.attach(".", a.refs)
+	return r
+}
+
+// bundler attaches the local files referenced by a document as assets.
+type bundler struct {
+	r    *RenderRequest
+	root fs.FS
+	seen map[string]bool
+}
+
+// attach adds the files refs point to, relative to dir, and the files
+// they reference in turn.
+func (b *bundler) attach(dir string, refs []string) {
+	for _, ref := range refs {
+		name, ok := localRef(dir, ref)
+		if !ok || b.seen[name] {
+			continue
+		}
+		b.seen[name] = true
+		if name == ".." || strings.HasPrefix(name, "../") {
+			b.r.fail("assets", "%q is outside the document's directory", ref)
+			return
+		}
+		data, err := fs.ReadFile(b.root, name)
+		if err != nil {
+			b.r.fail("assets", "%q: %v", ref, err)
+			return
+		}
+		b.r.Asset(name, data, assetType(name, data))
+		if strings.EqualFold(path.Ext(name), ".css") {
+			a := newAnalyzer()
+			a.css(string(data))
+			b.attach(path.Dir(name), a.refs)
+		}
+	}
+}
+
+// assetType returns the MIME type of a referenced file, by extension or,
+// for fonts, by content.
+func assetType(name string, data []byte) string {
+	if t := mime.TypeByExtension(path.Ext(name)); t != "" {
+		return t
+	}
+	if _, t := fontFormat(data); t != "" {
+		return t
+	}
+	return "application/octet-stream"
+}
+
+// localRef resolves a relative URL in a document in dir to a clean file
+// path. It reports false for remote, data:, fragment-only, and
+// root-relative URLs.
+func localRef(dir, ref string) (string, bool) {
+	u, err := url.Parse(strings.TrimSpace(ref))
+	if err != nil || u.Scheme != "" || u.Host != "" || u.Path == "" || strings.HasPrefix(u.Path, "/") {
+		return "", false
+	}
+	return path.Join(dir, u.Path), true
+}
@@ -0,0 +1,75 @@
+package forge
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestRenderFile(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"report/index.html": `<html><head><link rel="stylesheet" href="css/site.css?v=2"></head><body>
+<img src="img/logo%20dark.png" srcset="img/logo@2x.png 2x, https://cdn.example.com/x.png 3x">
+<img src="data:image/png;base64,AAAA"><a href="other.html">next</a><a href="#top">top</a>
+<div style="background: url('img/logo%20dark.png')"></div></body></html>`,
+		"report/css/site.css":        `@import "print.css"; body { font-family: Acme; } @font-face { src: url(../fonts/acme.woff2) }`,
+		"report/css/print.css":       `h1 { color: black }`,
+		"report/fonts/acme.woff2":    "wOF2glyphs",
+		"report/img/logo dark.png":   "png1",
+		"report/img/logo@2x.png":     "png2",
+		"report/other.html":          "not attached",
+		"report/unreferenced.png":    "not attached",
+		"outside.png":                "outside",
+		"report/broken/missing.html": `<img src="nope.png">`,
+		"report/broken/escape.html":  `<img src="../../outside.png">`,
+	})
+	c := NewClient("http://localhost:3000")
+
+	r := c.RenderFile(filepath.Join(dir, "report", "index.html"))
+	if err := r.validate(); err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]string{}
+	for _, a := range r.Payload().Assets {
+		got[a.Name] = a.MimeType + " " + string(a.Data)
+	}
+	want := map[string]string{
+		"css/site.css":      "text/css; charset=utf-8 @import \"print.css\"; body { font-family: Acme; } @font-face { src: url(../fonts/acme.woff2) }",
+		"css/print.css":     "text/css; charset=utf-8 h1 { color: black }",
+		"fonts/acme.woff2":  "font/woff2 wOF2glyphs",
+		"img/logo dark.png": "image/png png1",
+		"img/logo@2x.png":   "image/png png2",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("assets = %v", got)
+	}
+
+	for _, tc := range []struct {
+		name, field string
+	}{
+		{"report/nope.html", "file"},
+		{"report/broken/missing.html", "assets"},
+		{"report/broken/escape.html", "assets"},
+	} {
+		err := c.RenderFile(filepath.Join(dir, filepath.FromSlash(tc.name))).validate()
+		if ve, ok := err.(*ValidationError); !ok || ve.Field != tc.field {
+			t.Errorf("%s: err = %v, want field %s", tc.name, err, tc.field)
+		}
+	}
+}
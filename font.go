@@ -0,0 +1,183 @@
+package forge
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// FontStyle is a CSS font-style.
+type FontStyle string
+
+const (
+	FontStyleNormal  FontStyle = "normal"
+	FontStyleItalic  FontStyle = "italic"
+	FontStyleOblique FontStyle = "oblique"
+)
+
+// FontFace declares a font supplied with a render request, usable from
+// CSS font-family as if installed on the server. Its file travels as the
+// Asset named by Asset.
+type FontFace struct {
+	Family string    `json:"family"`
+	Weight int       `json:"weight"`
+	Style  FontStyle `json:"style"`
+	Asset  string    `json:"asset"`
+}
+
+// fontFormats maps font file signatures to their extension and MIME type.
+var fontFormats = []struct {
+	magic     string
+	ext, mime string
+}{
+	{"\x00\x01\x00\x00", ".ttf", "font/ttf"},
+	{"true", ".ttf", "font/ttf"},
+	{"OTTO", ".otf", "font/otf"},
+	{"ttcf", ".ttc", "font/collection"},
+	{"wOFF", ".woff", "font/woff"},
+	{"wOF2", ".woff2", "font/woff2"},
+}
+
+// fontFormat returns the extension and MIME type of a font file, or
+// empty strings if data is not a supported font.
+func fontFormat(data []byte) (ext, mimeType string) {
+	for _, f := range fontFormats {
+		if bytes.HasPrefix(data, []byte(f.magic)) {
+			return f.ext, f.mime
+		}
+	}
+	return "", ""
+}
+
+// Font supplies a TrueType, OpenType, or WOFF font with the request, so
+// CSS can use it by family name without installing it on the server.
+// weight is a CSS weight from 100 to 900 and style the face's style. Call
+// Font once per face, e.g. for regular and bold.
+func (r *RenderRequest) Font(family string, data []byte, weight int, style FontStyle) *RenderRequest {
+	if family == "" {
+		r.fail("fonts", "family is required")
+		return r
+	}
+	if weight < 100 || weight > 900 || weight%100 != 0 {
+		r.fail("fonts", "weight must be a multiple of 100 between 100 and 900, got %d", weight)
+		return r
+	}
+	switch style {
+	case FontStyleNormal, FontStyleItalic, FontStyleOblique:
+	default:
+		r.fail("fonts", "unknown style %q", style)
+		return r
+	}
+	ext, mimeType := fontFormat(data)
+	if ext == "" {
+		r.fail("fonts", "%s is not a TrueType, OpenType, or WOFF font", family)
+		return r
+	}
+	for _, f := range r.p.Fonts {
+		if f.Family == family && f.Weight == weight && f.Style == style {
+			r.fail("fonts", "duplicate face %s %d %s", family, weight, style)
+			return r
+		}
+	}
+
+	name := fmt.Sprintf("fonts/%s-%d-%s%s", family, weight, style, ext)
+	if r.Asset(name, data, mimeType); r.err != nil {
+		return r
+	}
+	r.p.Fonts = append(r.p.Fonts, FontFace{Family: family, Weight: weight, Style: style, Asset: name})
+	return r
+}
+
+// FontsClient administers the fonts installed on the server. Obtain one
+// with Client.Fonts.
+type FontsClient struct {
+	c *Client
+}
+
+// Fonts returns the client for the server's font administration API.
+func (c *Client) Fonts() *FontsClient {
+	return &FontsClient{c: c}
+}
+
+// InstalledFont is a font file installed on the server.
+type InstalledFont struct {
+	// Name identifies the file in Upload and Delete calls.
+	Name   string    `json:"name"`
+	Family string    `json:"family"`
+	Weight int       `json:"weight"`
+	Style  FontStyle `json:"style"`
+	// Size is the file size in bytes.
+	Size int64 `json:"size"`
+	// System is true for fonts shipped with the server image, which
+	// cannot be deleted.
+	System bool `json:"system,omitempty"`
+}
+
+// List returns the installed fonts.
+func (f *FontsClient) List(ctx context.Context) ([]InstalledFont, error) {
+	req, err := f.c.newRequest(ctx, http.MethodGet, "/fonts", nil, "")
+	if err != nil {
+		return nil, fmt.Errorf("forge: request error: %w", err)
+	}
+	data, err := f.c.call(req)
+	if err != nil {
+		return nil, err
+	}
+	var out struct {
+		Fonts []InstalledFont `json:"fonts"`
+	}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, fmt.Errorf("forge: decode fonts: %w", err)
+	}
+	return out.Fonts, nil
+}
+
+// Upload installs a TrueType, OpenType, or WOFF font file under name,
+// replacing any font of that name, and returns it as the server read it.
+// The font is available to all later renders.
+func (f *FontsClient) Upload(ctx context.Context, name string, data []byte) (*InstalledFont, error) {
+	path, err := fontPath(name)
+	if err != nil {
+		return nil, err
+	}
+	_, mimeType := fontFormat(data)
+	if mimeType == "" {
+		return nil, &ValidationError{Field: "data", Message: "not a TrueType, OpenType, or WOFF font"}
+	}
+
+	req, err := f.c.newRequest(ctx, http.MethodPut, path, data, mimeType)
+	if err != nil {
+		return nil, fmt.Errorf("forge: request error: %w", err)
+	}
+	body, err := f.c.callAdmin(req)
+	if err != nil {
+		return nil, err
+	}
+	var font InstalledFont
+	if err := json.Unmarshal(body, &font); err != nil {
+		return nil, fmt.Errorf("forge: decode font: %w", err)
+	}
+	return &font, nil
+}
+
+// Delete uninstalls the named font. Renders already in progress are not
+// affected.
+func (f *FontsClient) Delete(ctx context.Context, name string) error {
+	path, err := fontPath(name)
+	if err != nil {
+		return err
+	}
+	req, err := f.c.newRequest(ctx, http.MethodDelete, path, nil, "")
+	if err != nil {
+		return fmt.Errorf("forge: request error: %w", err)
+	}
+	_, err = f.c.callAdmin(req)
+	return err
+}
+
+// fontPath returns the API path of the named font.
+func fontPath(name string) (string, error) {
+	return resourcePath("/fonts", "name", "font name", name)
+}
@@ -0,0 +1,63 @@
+package forge
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFontsClient(t *testing.T) {
+	var deleted string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/fonts":
+			w.Write([]byte(`{"fonts":[{"name":"AcmeSans-Bold.ttf","family":"Acme Sans","weight":700,"style":"normal","size":48211}]}`))
+		case r.Method == http.MethodPut && r.URL.Path == "/fonts/Acme Sans.woff2":
+			data, _ := io.ReadAll(r.Body)
+			if r.Header.Get("Content-Type") != "font/woff2" || string(data) != "wOF2glyphs" {
+				t.Errorf("upload = %q, %q", r.Header.Get("Content-Type"), data)
+			}
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"name":"Acme Sans.woff2","family":"Acme Sans","weight":400,"style":"normal","size":10}`))
+		case r.Method == http.MethodDelete:
+			deleted = r.URL.Path
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+	fonts := NewClient(srv.URL).Fonts()
+	ctx := context.Background()
+
+	list, err := fonts.List(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 1 || list[0].Family != "Acme Sans" || list[0].Weight != 700 || list[0].Size != 48211 {
+		t.Errorf("List = %+v", list)
+	}
+
+	font, err := fonts.Upload(ctx, "Acme Sans.woff2", []byte("wOF2glyphs"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if font.Name != "Acme Sans.woff2" {
+		t.Errorf("Upload = %+v", font)
+	}
+	if _, err := fonts.Upload(ctx, "x.ttf", []byte("%PDF")); err == nil {
+		t.Error("Upload accepted a non-font")
+	}
+
+	if err := fonts.Delete(ctx, "AcmeSans-Bold.ttf"); err != nil {
+		t.Fatal(err)
+	}
+	if deleted != "/fonts/AcmeSans-Bold.ttf" {
+		t.Errorf("deleted %q", deleted)
+	}
+	if err := fonts.Delete(ctx, "../etc"); err == nil {
+		t.Error("Delete accepted a path")
+	}
+}
@@ -4,12 +4,12 @@
 package forge
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"io"
+	"crypto/tls"
+	"math"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 )
 
@@ -17,6 +17,29 @@ import (
 type Client struct {
 	baseURL    string
 	httpClient *http.Client
+	tlsConfig  *tls.Config
+	metrics    *metrics
+	retry      *RetryPolicy
+	locale     string
+	apiVersion string
+	redirect   RedirectPolicy
+	warnings   *warningLog
+	codec      PayloadCodec
+	journal    *journal
+	warn       func(msg string)
+	limits     *ComplexityLimits
+	transforms []ResultTransform
+
+	// err is a configuration error found by NewClient, reported by every
+	// request.
+	err error
+
+	deprecations deprecationRegistry
+
+	compress           bool
+	compressThreshold  int
+	multipartThreshold int
+	deadlineMargin     time.Duration
 }
 
 // Option configures a Client.
@@ -36,6 +59,25 @@ func WithHTTPClient(hc *http.Client) Option {
 	}
 }
 
+// WithCompression gzip-encodes request bodies larger than threshold bytes
+// and sends them with Content-Encoding: gzip. Large HTML and base64-embedded
+// files compress well; small bodies are sent as-is to avoid the overhead.
+func WithCompression(threshold int) Option {
+	return func(c *Client) {
+		c.compress = true
+		c.compressThreshold = threshold
+	}
+}
+
+// WithErrorLocale requests server error messages localized to the given
+// BCP 47 language tag (e.g. "de", "fr-CH") via Accept-Language. The
+// language-independent error code remains available as ServerError.Code.
+func WithErrorLocale(tag string) Option {
+	return func(c *Client) {
+		c.locale = tag
+	}
+}
+
 // NewClient creates a Forge client.
 func NewClient(baseURL string, opts ...Option) *Client {
 	// Strip trailing slashes.
@@ -48,271 +90,570 @@ func NewClient(baseURL string, opts ...Option) *Client {
 		httpClient: &http.Client{
 			Timeout: 120 * time.Second,
 		},
+		metrics:  newMetrics(),
+		warnings: &warningLog{},
+		codec:    JSONCodec,
+		warn:     defaultWarningHandler,
+
+		multipartThreshold: DefaultMultipartThreshold,
+		deadlineMargin:     DefaultDeadlineMargin,
 	}
 	for _, o := range opts {
 		o(c)
 	}
+	c.err = c.validateAPIVersion()
+	if c.tlsConfig != nil {
+		c.applyTLS()
+	}
+	if c.redirect != RedirectPolicyFollow {
+		c.applyRedirectPolicy()
+	}
 	return c
 }
 
 // RenderHTML starts a render request from an HTML string.
 func (c *Client) RenderHTML(html string) *RenderRequest {
-	return &RenderRequest{client: c, html: &html}
+	return &RenderRequest{client: c, p: RenderPayload{HTML: &html}}
 }
 
 // RenderURL starts a render request from a URL.
 func (c *Client) RenderURL(url string) *RenderRequest {
-	return &RenderRequest{client: c, url: &url}
+	return &RenderRequest{client: c, p: RenderPayload{URL: &url}}
+}
+
+// FromPayload starts a render request from a previously built payload,
+// such as one decoded from a stored JSON file. The payload is copied.
+func (c *Client) FromPayload(p *RenderPayload) *RenderRequest {
+	return &RenderRequest{client: c, p: *clonePayload(p)}
 }
 
 // Health checks if the server is healthy.
 func (c *Client) Health(ctx context.Context) (bool, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/health", nil)
+	req, err := c.newRequest(ctx, http.MethodGet, "/health", nil, "")
 	if err != nil {
 		return false, err
 	}
-	resp, err := c.httpClient.Do(req)
+	resp, _, err := c.do(req)
 	if err != nil {
-		return false, &ConnectionError{Cause: err}
+		return false, err
 	}
 	defer resp.Body.Close()
 	return resp.StatusCode == http.StatusOK, nil
 }
 
-// RenderRequest builds a render request.
+// RenderRequest builds a render request. Sending snapshots the payload,
+// so a request may be sent from several goroutines at once, but it must
+// not be modified while it is being sent; use Clone to derive per-goroutine
+// variants, or Frozen for a reusable compiled request.
 type RenderRequest struct {
-	client      *Client
-	html        *string
-	url         *string
-	format      string
-	width       *int
-	height      *int
-	paper       *string
-	orientation *string
-	margins     *string
-	flow        *string
-	density     *float64
-	background  *string
-	timeout     *int
-	colors      *int
-	palette     any
-	dither      *string
-	pdfTitle    *string
-	pdfAuthor   *string
-	pdfSubject  *string
-	pdfKeywords *string
-	pdfCreator  *string
-	pdfBookmarks    *bool
-	pdfPageNumbers  *bool
-	pdfWatermarkText     *string
-	pdfWatermarkImage    *string  // base64-encoded
-	pdfWatermarkOpacity  *float64
-	pdfWatermarkRotation *float64
-	pdfWatermarkColor    *string
-	pdfWatermarkFontSize *float64
-	pdfWatermarkScale    *float64
-	pdfWatermarkLayer    *string
-	pdfWatermarkPages    *string
-	pdfStandard          *PdfStandard
-	pdfEmbeddedFiles     []EmbeddedFile
-	pdfBarcodes          []BarcodeConfig
-	pdfMode              *string
-	pdfSignCertificate   *string
-	pdfSignPassword      *string
-	pdfSignName          *string
-	pdfSignReason        *string
-	pdfSignLocation      *string
-	pdfSignTimestampUrl  *string
-	pdfUserPassword      *string
-	pdfOwnerPassword     *string
-	pdfPermissions       *string
-	pdfAccessibility     *string
-	pdfLinearize         *bool
-	pdfLang              *string
-}
-
-// Format sets the output format (default: "pdf").
+	client *Client
+	p      RenderPayload
+	accept string
+	via    string
+	draft  bool
+	err    error
+}
+
+// quantize returns the request's quantize options, creating them if needed.
+func (r *RenderRequest) quantize() *QuantizeOptions {
+	if r.p.Quantize == nil {
+		r.p.Quantize = &QuantizeOptions{}
+	}
+	return r.p.Quantize
+}
+
+// pdf returns the request's PDF options, creating them if needed.
+func (r *RenderRequest) pdf() *PdfOptions {
+	if r.p.Pdf == nil {
+		r.p.Pdf = &PdfOptions{}
+	}
+	return r.p.Pdf
+}
+
+// watermark returns the request's watermark options, creating them if needed.
+func (r *RenderRequest) watermark() *WatermarkOptions {
+	pdf := r.pdf()
+	if pdf.Watermark == nil {
+		pdf.Watermark = &WatermarkOptions{}
+	}
+	return pdf.Watermark
+}
+
+// signature returns the request's signature options, creating them if needed.
+func (r *RenderRequest) signature() *SignOptions {
+	pdf := r.pdf()
+	if pdf.Signature == nil {
+		pdf.Signature = &SignOptions{}
+	}
+	return pdf.Signature
+}
+
+// encryption returns the request's encryption options, creating them if needed.
+func (r *RenderRequest) encryption() *EncryptionOptions {
+	pdf := r.pdf()
+	if pdf.Encryption == nil {
+		pdf.Encryption = &EncryptionOptions{}
+	}
+	return pdf.Encryption
+}
+
+// Format sets the output format (default: "pdf"). FormatAuto leaves the
+// choice to the server's content negotiation.
 func (r *RenderRequest) Format(f OutputFormat) *RenderRequest {
-	s := string(f)
-	r.format = s
+	r.p.Format = f
+	return r
+}
+
+// Accept sets the Accept header of the render request, e.g.
+// "application/pdf" or "image/png, application/pdf;q=0.5". Combined with
+// FormatAuto, the same request can yield different formats per caller.
+func (r *RenderRequest) Accept(mime string) *RenderRequest {
+	r.accept = mime
+	return r
+}
+
+// Via sends this request to the Forge server at baseURL instead of the
+// client's, e.g. a GPU node for large raster jobs. The client's transport,
+// retries, metrics, and other options still apply.
+func (r *RenderRequest) Via(baseURL string) *RenderRequest {
+	u, err := url.Parse(baseURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		r.fail("via", "invalid server URL %q", baseURL)
+		return r
+	}
+	r.via = strings.TrimRight(baseURL, "/")
 	return r
 }
 
 // Width sets the viewport width in CSS pixels.
 func (r *RenderRequest) Width(px int) *RenderRequest {
-	r.width = &px
+	r.p.Width = &px
 	return r
 }
 
 // Height sets the viewport height in CSS pixels.
 func (r *RenderRequest) Height(px int) *RenderRequest {
-	r.height = &px
+	r.p.Height = &px
 	return r
 }
 
-// Paper sets the paper size.
-func (r *RenderRequest) Paper(size string) *RenderRequest {
-	r.paper = &size
+// Paper sets the paper size. It replaces any size set by PaperSize.
+func (r *RenderRequest) Paper(size Paper) *RenderRequest {
+	r.p.Paper = &size
+	r.p.PaperSize = nil
+	return r
+}
+
+// PaperName sets the paper size by name, for sizes the server supports
+// that have no Paper constant.
+func (r *RenderRequest) PaperName(name string) *RenderRequest {
+	return r.Paper(Paper(name))
+}
+
+// PaperSize sets a custom paper size, e.g. PaperSize(80, 200, UnitMM) for a
+// receipt roll. It replaces any size set by Paper. Non-positive sizes and
+// unknown units are reported by Send.
+func (r *RenderRequest) PaperSize(width, height float64, unit Unit) *RenderRequest {
+	if _, ok := mmPerUnit[unit]; !ok || unit == "" {
+		r.fail("paper_size", "unknown unit %q", unit)
+		return r
+	}
+	for _, v := range []float64{width, height} {
+		if v <= 0 || math.IsNaN(v) || math.IsInf(v, 0) {
+			r.fail("paper_size", "invalid dimension %v", v)
+			return r
+		}
+	}
+	r.p.PaperSize = &PaperDimensions{Width: width, Height: height, Unit: unit}
+	r.p.Paper = nil
+	return r
+}
+
+// PreferCSSPageSize lets a CSS @page size declaration override the Paper
+// and PaperSize settings when rendering to PDF.
+func (r *RenderRequest) PreferCSSPageSize(enabled bool) *RenderRequest {
+	r.p.PreferCSSPageSize = &enabled
 	return r
 }
 
 // Orientation sets the page orientation.
 func (r *RenderRequest) Orientation(o Orientation) *RenderRequest {
-	s := string(o)
-	r.orientation = &s
+	r.p.Orientation = &o
 	return r
 }
 
-// Margins sets page margins.
+// Margins sets page margins as a preset ("default", "none", "narrow") or a
+// "T,R,B,L" string in millimeters. MarginsAll, MarginsTRBL, and MarginsWith
+// build the string from numbers in any Unit and validate it client-side.
 func (r *RenderRequest) Margins(m string) *RenderRequest {
-	r.margins = &m
+	r.p.Margins = &m
 	return r
 }
 
 // Flow sets the document flow mode.
 func (r *RenderRequest) Flow(f Flow) *RenderRequest {
-	s := string(f)
-	r.flow = &s
+	r.p.Flow = &f
+	return r
+}
+
+// MaxPageHeight caps page height in CSS pixels for FlowHybrid, which
+// breaks long content into pages only where it exceeds the cap.
+func (r *RenderRequest) MaxPageHeight(px int) *RenderRequest {
+	if px <= 0 {
+		r.fail("max_page_height", "must be positive, got %d", px)
+		return r
+	}
+	r.p.MaxPageHeight = &px
 	return r
 }
 
 // Density sets the output DPI.
 func (r *RenderRequest) Density(dpi float64) *RenderRequest {
-	r.density = &dpi
+	r.p.Density = &dpi
+	return r
+}
+
+// DeviceScaleFactor sets the number of device pixels per CSS pixel for
+// raster output, e.g. 2 for retina-quality screenshots. Unlike Density,
+// which changes the output DPI, and Scale, which zooms the content, the
+// CSS layout is unaffected: a 1280px-wide viewport still lays out at
+// 1280 CSS pixels but yields a 2560px-wide image. The factor must be
+// positive.
+func (r *RenderRequest) DeviceScaleFactor(f float64) *RenderRequest {
+	if !(f > 0) {
+		r.fail("device_scale_factor", "must be positive, got %v", f)
+		return r
+	}
+	r.p.DeviceScaleFactor = &f
+	return r
+}
+
+// Scale sets the page zoom factor, e.g. 0.8 to shrink wide content to fit
+// the paper width. The factor must be positive.
+func (r *RenderRequest) Scale(factor float64) *RenderRequest {
+	if !(factor > 0) {
+		r.fail("zoom", "scale factor must be positive, got %v", factor)
+		return r
+	}
+	r.p.Zoom = &factor
+	return r
+}
+
+// Clip captures only the given rectangle of the page, in CSS pixels from
+// the top-left corner, instead of the full viewport. Image formats only.
+func (r *RenderRequest) Clip(x, y, width, height float64) *RenderRequest {
+	if x < 0 || y < 0 {
+		r.fail("clip", "origin must not be negative, got (%g, %g)", x, y)
+		return r
+	}
+	if width <= 0 || height <= 0 {
+		r.fail("clip", "size must be positive, got %gx%g", width, height)
+		return r
+	}
+	r.p.Clip = &ClipRect{X: x, Y: y, Width: width, Height: height}
+	return r
+}
+
+// CaptureSelector captures only the bounding box of the first element
+// matching the CSS selector, e.g. "#chart". Options can add padding or
+// extend the box to include the element's box-shadow. Image formats only;
+// it conflicts with Clip.
+func (r *RenderRequest) CaptureSelector(css string, opts ...func(*CaptureOptions)) *RenderRequest {
+	if css == "" {
+		r.fail("capture.selector", "must not be empty")
+		return r
+	}
+	capture := CaptureOptions{Selector: css}
+	for _, opt := range opts {
+		opt(&capture)
+	}
+	r.p.Capture = &capture
 	return r
 }
 
 // Background sets the CSS background color.
 func (r *RenderRequest) Background(color string) *RenderRequest {
-	r.background = &color
+	r.p.Background = &color
+	return r
+}
+
+// Transparent omits the page background so image output keeps an alpha
+// channel for compositing. It conflicts with Background.
+func (r *RenderRequest) Transparent(enabled bool) *RenderRequest {
+	r.p.Transparent = &enabled
+	return r
+}
+
+// DocumentLocale stamps the output with a BCP 47 language tag, e.g.
+// "de-CH". It sets the PDF document language (like PdfLang) and is echoed
+// back in RenderResponse.Locale, so downstream services can route the
+// document without inspecting its content.
+func (r *RenderRequest) DocumentLocale(tag string) *RenderRequest {
+	if !validLanguageTag(tag) {
+		r.fail("locale", "invalid language tag %q", tag)
+		return r
+	}
+	r.p.Locale = &tag
+	r.pdf().DocumentLang = &tag
+	return r
+}
+
+// validLanguageTag reports whether tag is syntactically a BCP 47 tag:
+// alphanumeric subtags of 1-8 characters separated by hyphens, starting
+// with a 2-8 letter language.
+func validLanguageTag(tag string) bool {
+	for i, sub := range strings.Split(tag, "-") {
+		if len(sub) < 1 || len(sub) > 8 || (i == 0 && len(sub) < 2) {
+			return false
+		}
+		for _, c := range sub {
+			alpha := c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+			if !alpha && (i == 0 || c < '0' || c > '9') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// JavaScript enables or disables script execution in the rendered page
+// (enabled by default). Disable it for untrusted HTML to reduce the risk of
+// server-side request forgery and data exfiltration.
+func (r *RenderRequest) JavaScript(enabled bool) *RenderRequest {
+	r.p.JavaScript = &enabled
+	return r
+}
+
+// InjectCSS adds a stylesheet to the page before rendering, after the
+// page's own styles, e.g. to hide navigation bars on a fetched URL.
+// Stylesheets apply in the order added.
+func (r *RenderRequest) InjectCSS(css string) *RenderRequest {
+	if strings.TrimSpace(css) == "" {
+		r.fail("styles", "must not be empty")
+		return r
+	}
+	r.p.Styles = append(r.p.Styles, css)
 	return r
 }
 
-// Timeout sets the page load timeout in seconds.
+// Grayscale converts PDF and image output to grayscale.
+func (r *RenderRequest) Grayscale(enabled bool) *RenderRequest {
+	r.p.Grayscale = &enabled
+	return r
+}
+
+// Timeout sets the page load timeout in seconds. When the context passed to
+// Send has a deadline, the timeout is capped to fit it (see
+// WithDeadlineMargin).
 func (r *RenderRequest) Timeout(seconds int) *RenderRequest {
-	r.timeout = &seconds
+	r.p.Timeout = &seconds
+	return r
+}
+
+// Engine selects the server's rendering engine, e.g. EngineTypeset for
+// print layouts or EngineWebKit for CSS features Chromium renders
+// differently. The server default is used when unset.
+func (r *RenderRequest) Engine(e Engine) *RenderRequest {
+	r.p.Engine = &e
+	return r
+}
+
+// EmulateMedia renders the page with the stylesheets for the given CSS
+// media type, e.g. MediaScreen to make a PDF look like the page in a
+// browser. When unset, the server picks one per engine and format.
+func (r *RenderRequest) EmulateMedia(m MediaType) *RenderRequest {
+	r.p.Media = &m
+	return r
+}
+
+// WorkerAffinity routes requests with the same key to the same worker
+// where possible, so warm caches of fonts and assets are reused.
+func (r *RenderRequest) WorkerAffinity(key string) *RenderRequest {
+	if key == "" {
+		r.fail("worker_affinity", "must not be empty")
+		return r
+	}
+	r.p.WorkerAffinity = &key
 	return r
 }
 
 // Colors sets the number of colors for quantization (2-256).
 func (r *RenderRequest) Colors(n int) *RenderRequest {
-	r.colors = &n
+	r.quantize().Colors = &n
 	return r
 }
 
 // Palette sets a built-in palette preset.
 func (r *RenderRequest) Palette(p Palette) *RenderRequest {
-	r.palette = string(p)
+	r.quantize().Palette = string(p)
 	return r
 }
 
 // CustomPalette sets a custom palette of hex color strings.
 func (r *RenderRequest) CustomPalette(colors []string) *RenderRequest {
-	r.palette = colors
+	r.quantize().Palette = colors
 	return r
 }
 
 // Dither sets the dithering algorithm.
 func (r *RenderRequest) Dither(method DitherMethod) *RenderRequest {
-	s := string(method)
-	r.dither = &s
+	r.quantize().Dither = &method
 	return r
 }
 
 // PdfTitle sets the PDF document title metadata.
 func (r *RenderRequest) PdfTitle(title string) *RenderRequest {
-	r.pdfTitle = &title
+	r.pdf().Title = &title
 	return r
 }
 
 // PdfAuthor sets the PDF document author metadata.
 func (r *RenderRequest) PdfAuthor(author string) *RenderRequest {
-	r.pdfAuthor = &author
+	r.pdf().Author = &author
 	return r
 }
 
 // PdfSubject sets the PDF document subject metadata.
 func (r *RenderRequest) PdfSubject(subject string) *RenderRequest {
-	r.pdfSubject = &subject
+	r.pdf().Subject = &subject
 	return r
 }
 
 // PdfKeywords sets the PDF document keywords metadata (comma-separated).
 func (r *RenderRequest) PdfKeywords(keywords string) *RenderRequest {
-	r.pdfKeywords = &keywords
+	r.pdf().Keywords = &keywords
 	return r
 }
 
 // PdfCreator sets the PDF document creator metadata.
 func (r *RenderRequest) PdfCreator(creator string) *RenderRequest {
-	r.pdfCreator = &creator
+	r.pdf().Creator = &creator
 	return r
 }
 
-// PdfBookmarks enables or disables PDF bookmarks from headings.
+// PdfCustomProperty adds an entry to the PDF document Info dictionary,
+// e.g. PdfCustomProperty("DocumentClass", "invoice"). Setting a key again
+// replaces its value. Standard keys such as Title have their own methods
+// and are rejected here.
+func (r *RenderRequest) PdfCustomProperty(key, value string) *RenderRequest {
+	switch key {
+	case "Title", "Author", "Subject", "Keywords", "Creator", "Producer", "CreationDate", "ModDate", "Trapped":
+		r.fail("pdf.custom_properties", "%s is a standard property", key)
+		return r
+	}
+	if key == "" || strings.ContainsFunc(key, func(c rune) bool {
+		return c <= ' ' || c > '~' || strings.ContainsRune("()<>[]{}/%#", c)
+	}) {
+		r.fail("pdf.custom_properties", "invalid property name %q", key)
+		return r
+	}
+	pdf := r.pdf()
+	if pdf.CustomProperties == nil {
+		pdf.CustomProperties = make(map[string]string)
+	}
+	pdf.CustomProperties[key] = value
+	return r
+}
+
+// PdfBookmarks enables or disables PDF bookmarks from headings. Use
+// PdfBookmarkSelectors to generate them from other elements.
 func (r *RenderRequest) PdfBookmarks(enabled bool) *RenderRequest {
-	r.pdfBookmarks = &enabled
+	r.pdf().Bookmarks = &enabled
+	return r
+}
+
+// PdfNamedDestinations makes every element with an id a named destination
+// of the same name, so links such as "report.pdf#section-3" open at that
+// element.
+func (r *RenderRequest) PdfNamedDestinations(enabled bool) *RenderRequest {
+	r.pdf().NamedDestinations = &enabled
+	return r
+}
+
+// PdfLinks sets how hyperlinks appear in PDF output, e.g. for a print copy
+// with URLs spelled out:
+//
+//	req.PdfLinks(forge.LinkOptions{Internal: true, FootnoteURLs: true})
+func (r *RenderRequest) PdfLinks(opts LinkOptions) *RenderRequest {
+	r.pdf().Links = &opts
 	return r
 }
 
 // PdfPageNumbers enables or disables "Page X of Y" footers on each page.
 func (r *RenderRequest) PdfPageNumbers(enabled bool) *RenderRequest {
-	r.pdfPageNumbers = &enabled
+	r.pdf().PageNumbers = &enabled
+	return r
+}
+
+// PdfPageNumbering enables page numbers with a custom start, format,
+// position, and font size.
+func (r *RenderRequest) PdfPageNumbering(n PageNumbering) *RenderRequest {
+	pdf := r.pdf()
+	if pdf.PageNumbers == nil {
+		enabled := true
+		pdf.PageNumbers = &enabled
+	}
+	pdf.PageNumbering = &n
 	return r
 }
 
 // PdfWatermarkText sets the watermark text overlay on each PDF page.
 func (r *RenderRequest) PdfWatermarkText(text string) *RenderRequest {
-	r.pdfWatermarkText = &text
+	r.watermark().Text = &text
 	return r
 }
 
 // PdfWatermarkImage sets the watermark image (base64-encoded PNG/JPEG).
 func (r *RenderRequest) PdfWatermarkImage(base64Data string) *RenderRequest {
-	r.pdfWatermarkImage = &base64Data
+	r.watermark().ImageData = &base64Data
 	return r
 }
 
 // PdfWatermarkOpacity sets the watermark opacity (0.0-1.0, default 0.15).
 func (r *RenderRequest) PdfWatermarkOpacity(opacity float64) *RenderRequest {
-	r.pdfWatermarkOpacity = &opacity
+	r.watermark().Opacity = &opacity
 	return r
 }
 
 // PdfWatermarkRotation sets the watermark rotation in degrees (default -45).
 func (r *RenderRequest) PdfWatermarkRotation(degrees float64) *RenderRequest {
-	r.pdfWatermarkRotation = &degrees
+	r.watermark().Rotation = &degrees
 	return r
 }
 
 // PdfWatermarkColor sets the watermark text color as hex (default "#888888").
 func (r *RenderRequest) PdfWatermarkColor(hex string) *RenderRequest {
-	r.pdfWatermarkColor = &hex
+	r.watermark().Color = &hex
 	return r
 }
 
 // PdfWatermarkFontSize sets the watermark font size in PDF points.
 func (r *RenderRequest) PdfWatermarkFontSize(size float64) *RenderRequest {
-	r.pdfWatermarkFontSize = &size
+	r.watermark().FontSize = &size
 	return r
 }
 
 // PdfWatermarkScale sets the watermark image scale (0.0-1.0, default 0.5).
 func (r *RenderRequest) PdfWatermarkScale(scale float64) *RenderRequest {
-	r.pdfWatermarkScale = &scale
+	r.watermark().Scale = &scale
 	return r
 }
 
 // PdfWatermarkLayer sets the watermark layer position.
 func (r *RenderRequest) PdfWatermarkLayer(layer WatermarkLayer) *RenderRequest {
-	s := string(layer)
-	r.pdfWatermarkLayer = &s
+	r.watermark().Layer = &layer
 	return r
 }
 
-// PdfStandard sets the PDF standard compliance level.
+// PdfStandard sets the PDF standard compliance level. Options the chosen
+// PDF/A level forbids, such as encryption, or embedded files before
+// PDF/A-3, are rejected before sending.
 func (r *RenderRequest) PdfStandard(standard PdfStandard) *RenderRequest {
-	r.pdfStandard = &standard
+	if standard == PdfStandardUA1 {
+		r.fail("pdf.standard", "use PdfAccessibility(AccessibilityPdfUa1) for PDF/UA")
+		return r
+	}
+	r.pdf().Standard = &standard
 	return r
 }
 
@@ -322,447 +663,186 @@ func (r *RenderRequest) PdfAttach(path, data string, opts ...func(*EmbeddedFile)
 	for _, opt := range opts {
 		opt(&ef)
 	}
-	r.pdfEmbeddedFiles = append(r.pdfEmbeddedFiles, ef)
+	pdf := r.pdf()
+	pdf.EmbeddedFiles = append(pdf.EmbeddedFiles, ef)
 	return r
 }
 
 // PdfWatermarkPages sets which pages the watermark applies to (e.g. "1,3-5").
 func (r *RenderRequest) PdfWatermarkPages(pages string) *RenderRequest {
-	r.pdfWatermarkPages = &pages
+	r.watermark().Pages = &pages
 	return r
 }
 
 // PdfBarcode adds a barcode with the given type and data.
 func (r *RenderRequest) PdfBarcode(barcodeType BarcodeType, data string) *RenderRequest {
-	r.pdfBarcodes = append(r.pdfBarcodes, BarcodeConfig{Type: barcodeType, Data: data})
-	return r
+	return r.PdfBarcodeWith(BarcodeConfig{Type: barcodeType, Data: data})
 }
 
 // PdfBarcodeWith adds a fully-configured barcode.
 func (r *RenderRequest) PdfBarcodeWith(config BarcodeConfig) *RenderRequest {
-	r.pdfBarcodes = append(r.pdfBarcodes, config)
+	pdf := r.pdf()
+	pdf.Barcodes = append(pdf.Barcodes, config)
 	return r
 }
 
 // PdfMode sets the PDF rendering mode (auto, vector, or raster).
 func (r *RenderRequest) PdfMode(mode PdfMode) *RenderRequest {
-	s := string(mode)
-	r.pdfMode = &s
+	r.pdf().Mode = &mode
 	return r
 }
 
 // PdfSignCertificate sets the base64-encoded PKCS#12 certificate for PDF signing.
 func (r *RenderRequest) PdfSignCertificate(data string) *RenderRequest {
-	r.pdfSignCertificate = &data
+	r.signature().CertificateData = data
 	return r
 }
 
 // PdfSignPassword sets the password for the PKCS#12 certificate.
 func (r *RenderRequest) PdfSignPassword(password string) *RenderRequest {
-	r.pdfSignPassword = &password
+	r.signature().Password = password
 	return r
 }
 
 // PdfSignName sets the signer name for the PDF signature.
 func (r *RenderRequest) PdfSignName(name string) *RenderRequest {
-	r.pdfSignName = &name
+	r.signature().SignerName = name
 	return r
 }
 
 // PdfSignReason sets the reason for the PDF signature.
 func (r *RenderRequest) PdfSignReason(reason string) *RenderRequest {
-	r.pdfSignReason = &reason
+	r.signature().Reason = reason
 	return r
 }
 
 // PdfSignLocation sets the location for the PDF signature.
 func (r *RenderRequest) PdfSignLocation(location string) *RenderRequest {
-	r.pdfSignLocation = &location
+	r.signature().Location = location
 	return r
 }
 
 // PdfSignTimestampUrl sets the RFC 3161 timestamp server URL for the PDF signature.
 func (r *RenderRequest) PdfSignTimestampUrl(url string) *RenderRequest {
-	r.pdfSignTimestampUrl = &url
+	r.signature().TimestampURL = url
 	return r
 }
 
 // PdfUserPassword sets the user password for PDF encryption (required to open).
 func (r *RenderRequest) PdfUserPassword(password string) *RenderRequest {
-	r.pdfUserPassword = &password
+	r.encryption().UserPassword = password
 	return r
 }
 
 // PdfOwnerPassword sets the owner password for PDF encryption (required to edit).
 func (r *RenderRequest) PdfOwnerPassword(password string) *RenderRequest {
-	r.pdfOwnerPassword = &password
+	r.encryption().OwnerPassword = password
 	return r
 }
 
 // PdfPermissions sets the PDF permission flags (comma-separated, e.g. "print,copy").
 func (r *RenderRequest) PdfPermissions(permissions string) *RenderRequest {
-	r.pdfPermissions = &permissions
+	r.encryption().Permissions = permissions
 	return r
 }
 
 // PdfAccessibility sets the PDF accessibility compliance level.
 func (r *RenderRequest) PdfAccessibility(level AccessibilityLevel) *RenderRequest {
-	s := string(level)
-	r.pdfAccessibility = &s
+	r.pdf().Accessibility = &level
+	return r
+}
+
+// PdfForms turns the document's <input>, <select>, and <textarea> elements
+// into fillable PDF form fields named after their name attributes, instead
+// of flattening them into page content. Raster mode is rejected.
+func (r *RenderRequest) PdfForms(enabled bool) *RenderRequest {
+	r.pdf().Forms = &enabled
 	return r
 }
 
 // PdfLinearize enables or disables PDF linearization (fast web view).
 func (r *RenderRequest) PdfLinearize(enabled bool) *RenderRequest {
-	r.pdfLinearize = &enabled
+	r.pdf().Linearize = &enabled
 	return r
 }
 
 // PdfLang sets the document language as a BCP 47 tag (e.g. "en-US"). Required for PDF/UA-1.
 func (r *RenderRequest) PdfLang(lang string) *RenderRequest {
-	r.pdfLang = &lang
+	r.pdf().DocumentLang = &lang
 	return r
 }
 
-// buildPayload builds the JSON payload map.
-func (r *RenderRequest) buildPayload() map[string]any {
-	p := map[string]any{}
-
-	if r.html != nil {
-		p["html"] = *r.html
-	}
-	if r.url != nil {
-		p["url"] = *r.url
-	}
-
-	format := r.format
-	if format == "" {
-		format = "pdf"
-	}
-	p["format"] = format
-
-	if r.width != nil {
-		p["width"] = *r.width
-	}
-	if r.height != nil {
-		p["height"] = *r.height
-	}
-	if r.paper != nil {
-		p["paper"] = *r.paper
-	}
-	if r.orientation != nil {
-		p["orientation"] = *r.orientation
-	}
-	if r.margins != nil {
-		p["margins"] = *r.margins
-	}
-	if r.flow != nil {
-		p["flow"] = *r.flow
-	}
-	if r.density != nil {
-		p["density"] = *r.density
-	}
-	if r.background != nil {
-		p["background"] = *r.background
-	}
-	if r.timeout != nil {
-		p["timeout"] = *r.timeout
+// PdfLanguage sets the document language screen readers announce, as a
+// BCP 47 tag (e.g. "en-US"). Unlike PdfLang it rejects malformed tags.
+func (r *RenderRequest) PdfLanguage(tag string) *RenderRequest {
+	if !validLanguageTag(tag) {
+		r.fail("pdf.document_lang", "invalid language tag %q", tag)
+		return r
 	}
+	return r.PdfLang(tag)
+}
 
-	if r.colors != nil || r.palette != nil || r.dither != nil {
-		q := map[string]any{}
-		if r.colors != nil {
-			q["colors"] = *r.colors
-		}
-		if r.palette != nil {
-			q["palette"] = r.palette
-		}
-		if r.dither != nil {
-			q["dither"] = *r.dither
-		}
-		p["quantize"] = q
-	}
-
-	hasWatermark := r.pdfWatermarkText != nil || r.pdfWatermarkImage != nil ||
-		r.pdfWatermarkOpacity != nil || r.pdfWatermarkRotation != nil ||
-		r.pdfWatermarkColor != nil || r.pdfWatermarkFontSize != nil ||
-		r.pdfWatermarkScale != nil || r.pdfWatermarkLayer != nil ||
-		r.pdfWatermarkPages != nil
-
-	hasSignature := r.pdfSignCertificate != nil || r.pdfSignPassword != nil ||
-		r.pdfSignName != nil || r.pdfSignReason != nil || r.pdfSignLocation != nil ||
-		r.pdfSignTimestampUrl != nil
-
-	hasEncryption := r.pdfUserPassword != nil || r.pdfOwnerPassword != nil ||
-		r.pdfPermissions != nil
-
-	if r.pdfTitle != nil || r.pdfAuthor != nil || r.pdfSubject != nil ||
-		r.pdfKeywords != nil || r.pdfCreator != nil || r.pdfBookmarks != nil ||
-		r.pdfPageNumbers != nil || hasWatermark ||
-		r.pdfStandard != nil || len(r.pdfEmbeddedFiles) > 0 || len(r.pdfBarcodes) > 0 ||
-		r.pdfMode != nil || hasSignature || hasEncryption || r.pdfAccessibility != nil ||
-		r.pdfLinearize != nil || r.pdfLang != nil {
-		pdf := map[string]any{}
-		if r.pdfTitle != nil {
-			pdf["title"] = *r.pdfTitle
-		}
-		if r.pdfAuthor != nil {
-			pdf["author"] = *r.pdfAuthor
-		}
-		if r.pdfSubject != nil {
-			pdf["subject"] = *r.pdfSubject
-		}
-		if r.pdfKeywords != nil {
-			pdf["keywords"] = *r.pdfKeywords
-		}
-		if r.pdfCreator != nil {
-			pdf["creator"] = *r.pdfCreator
-		}
-		if r.pdfBookmarks != nil {
-			pdf["bookmarks"] = *r.pdfBookmarks
-		}
-		if r.pdfPageNumbers != nil {
-			pdf["page_numbers"] = *r.pdfPageNumbers
-		}
-		if hasWatermark {
-			wm := map[string]any{}
-			if r.pdfWatermarkText != nil {
-				wm["text"] = *r.pdfWatermarkText
-			}
-			if r.pdfWatermarkImage != nil {
-				wm["image_data"] = *r.pdfWatermarkImage
-			}
-			if r.pdfWatermarkOpacity != nil {
-				wm["opacity"] = *r.pdfWatermarkOpacity
-			}
-			if r.pdfWatermarkRotation != nil {
-				wm["rotation"] = *r.pdfWatermarkRotation
-			}
-			if r.pdfWatermarkColor != nil {
-				wm["color"] = *r.pdfWatermarkColor
-			}
-			if r.pdfWatermarkFontSize != nil {
-				wm["font_size"] = *r.pdfWatermarkFontSize
-			}
-			if r.pdfWatermarkScale != nil {
-				wm["scale"] = *r.pdfWatermarkScale
-			}
-			if r.pdfWatermarkLayer != nil {
-				wm["layer"] = *r.pdfWatermarkLayer
-			}
-			if r.pdfWatermarkPages != nil {
-				wm["pages"] = *r.pdfWatermarkPages
-			}
-			pdf["watermark"] = wm
-		}
-		if r.pdfStandard != nil {
-			pdf["standard"] = string(*r.pdfStandard)
-		}
-		if len(r.pdfEmbeddedFiles) > 0 {
-			files := make([]map[string]interface{}, len(r.pdfEmbeddedFiles))
-			for i, ef := range r.pdfEmbeddedFiles {
-				f := map[string]interface{}{
-					"path": ef.Path,
-					"data": ef.Data,
-				}
-				if ef.MimeType != "" {
-					f["mime_type"] = ef.MimeType
-				}
-				if ef.Description != "" {
-					f["description"] = ef.Description
-				}
-				if ef.Relationship != "" {
-					f["relationship"] = string(ef.Relationship)
-				}
-				files[i] = f
-			}
-			pdf["embedded_files"] = files
-		}
-		if len(r.pdfBarcodes) > 0 {
-			barcodes := make([]map[string]interface{}, len(r.pdfBarcodes))
-			for i, bc := range r.pdfBarcodes {
-				b := map[string]interface{}{
-					"type": string(bc.Type),
-					"data": bc.Data,
-				}
-				if bc.X != nil {
-					b["x"] = *bc.X
-				}
-				if bc.Y != nil {
-					b["y"] = *bc.Y
-				}
-				if bc.Width != nil {
-					b["width"] = *bc.Width
-				}
-				if bc.Height != nil {
-					b["height"] = *bc.Height
-				}
-				if bc.Anchor != nil {
-					b["anchor"] = string(*bc.Anchor)
-				}
-				if bc.Foreground != nil {
-					b["foreground"] = *bc.Foreground
-				}
-				if bc.Background != nil {
-					b["background"] = *bc.Background
-				}
-				if bc.DrawBg != nil {
-					b["draw_background"] = *bc.DrawBg
-				}
-				if bc.Pages != nil {
-					b["pages"] = *bc.Pages
-				}
-				barcodes[i] = b
-			}
-			pdf["barcodes"] = barcodes
-		}
-		if r.pdfMode != nil {
-			pdf["mode"] = *r.pdfMode
-		}
-		if hasSignature {
-			sig := map[string]any{}
-			if r.pdfSignCertificate != nil {
-				sig["certificate_data"] = *r.pdfSignCertificate
-			}
-			if r.pdfSignPassword != nil {
-				sig["password"] = *r.pdfSignPassword
-			}
-			if r.pdfSignName != nil {
-				sig["signer_name"] = *r.pdfSignName
-			}
-			if r.pdfSignReason != nil {
-				sig["reason"] = *r.pdfSignReason
-			}
-			if r.pdfSignLocation != nil {
-				sig["location"] = *r.pdfSignLocation
-			}
-			if r.pdfSignTimestampUrl != nil {
-				sig["timestamp_url"] = *r.pdfSignTimestampUrl
-			}
-			pdf["signature"] = sig
+// PdfTagging sets how the document is tagged for screen readers, instead
+// of leaving alternative text and heading structure to the server's
+// inference.
+func (r *RenderRequest) PdfTagging(opts TaggingOptions) *RenderRequest {
+	for src, alt := range opts.AltTextMap {
+		if src == "" {
+			r.fail("pdf.tagging", "alt text %q has an empty image source", alt)
+			return r
 		}
-		if hasEncryption {
-			enc := map[string]any{}
-			if r.pdfUserPassword != nil {
-				enc["user_password"] = *r.pdfUserPassword
-			}
-			if r.pdfOwnerPassword != nil {
-				enc["owner_password"] = *r.pdfOwnerPassword
-			}
-			if r.pdfPermissions != nil {
-				enc["permissions"] = *r.pdfPermissions
-			}
-			pdf["encryption"] = enc
-		}
-		if r.pdfAccessibility != nil {
-			pdf["accessibility"] = *r.pdfAccessibility
-		}
-		if r.pdfLinearize != nil {
-			pdf["linearize"] = *r.pdfLinearize
-		}
-		if r.pdfLang != nil {
-			pdf["document_lang"] = *r.pdfLang
-		}
-		p["pdf"] = pdf
 	}
+	r.pdf().Tagging = &opts
+	return r
+}
 
-	return p
+// Payload returns a copy of the payload the request will send.
+func (r *RenderRequest) Payload() *RenderPayload {
+	p := *clonePayload(&r.p)
+	switch p.Format {
+	case "":
+		p.Format = FormatPDF
+	case FormatAuto:
+		p.Format = ""
+	}
+	r.applyDraft(&p)
+	return &p
 }
 
 // Send executes the render request and returns the raw output bytes.
 func (r *RenderRequest) Send(ctx context.Context) ([]byte, error) {
-	payload := r.buildPayload()
-
-	body, err := json.Marshal(payload)
-	if err != nil {
-		return nil, fmt.Errorf("forge: marshal error: %w", err)
-	}
-
-	req, err := http.NewRequestWithContext(
-		ctx, http.MethodPost,
-		r.client.baseURL+"/render",
-		bytes.NewReader(body),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("forge: request error: %w", err)
-	}
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := r.client.httpClient.Do(req)
+	res, err := r.SendWithWarnings(ctx)
 	if err != nil {
-		return nil, &ConnectionError{Cause: err}
-	}
-	defer resp.Body.Close()
-
-	data, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("forge: read body: %w", err)
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		var errResp struct {
-			Error string `json:"error"`
-		}
-		msg := fmt.Sprintf("HTTP %d", resp.StatusCode)
-		if json.Unmarshal(data, &errResp) == nil && errResp.Error != "" {
-			msg = errResp.Error
-		}
-		return nil, &ServerError{
-			StatusCode: resp.StatusCode,
-			Message:    msg,
-		}
+		return nil, err
 	}
-
-	return data, nil
+	return res.Data, nil
 }
 
 // SendWithWarnings sends the render request and returns the full response including warnings.
 // Warnings are CSS compatibility notices emitted by the Forge server as X-Forge-Warning headers.
 func (r *RenderRequest) SendWithWarnings(ctx context.Context) (*RenderResponse, error) {
-	payload := r.buildPayload()
-
-	body, err := json.Marshal(payload)
+	c, err := r.compile()
 	if err != nil {
-		return nil, fmt.Errorf("forge: marshal error: %w", err)
+		return nil, err
 	}
+	return c.sendWithWarnings(ctx)
+}
 
-	req, err := http.NewRequestWithContext(
-		ctx, http.MethodPost,
-		r.client.baseURL+"/render",
-		bytes.NewReader(body),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("forge: request error: %w", err)
-	}
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := r.client.httpClient.Do(req)
-	if err != nil {
-		return nil, &ConnectionError{Cause: err}
-	}
-	defer resp.Body.Close()
+// SendRaw sends the render request and returns the server's response as-is,
+// including non-2xx responses. The caller must close the response body.
+//
+// SendRaw is an escape hatch for streaming output or reading headers the
+// typed APIs do not expose; retries, compression, and metrics still apply.
+func (r *RenderRequest) SendRaw(ctx context.Context) (*http.Response, error) {
+	resp, _, err := r.send(ctx)
+	return resp, err
+}
 
-	data, err := io.ReadAll(resp.Body)
+// send validates the request and executes it.
+func (r *RenderRequest) send(ctx context.Context) (*http.Response, *exchange, error) {
+	c, err := r.compile()
 	if err != nil {
-		return nil, fmt.Errorf("forge: read body: %w", err)
+		return nil, nil, err
 	}
-
-	if resp.StatusCode != http.StatusOK {
-		var errResp struct {
-			Error string `json:"error"`
-		}
-		msg := fmt.Sprintf("HTTP %d", resp.StatusCode)
-		if json.Unmarshal(data, &errResp) == nil && errResp.Error != "" {
-			msg = errResp.Error
-		}
-		return nil, &ServerError{
-			StatusCode: resp.StatusCode,
-			Message:    msg,
-		}
-	}
-
-	return &RenderResponse{
-		Data:     data,
-		Warnings: resp.Header.Values("X-Forge-Warning"),
-	}, nil
+	return c.send(ctx)
 }
@@ -0,0 +1,232 @@
+// Package forgeload replays a corpus of render requests against a Forge
+// server at a fixed rate and reports latency percentiles and error rates.
+//
+// It is intended for soak tests and capacity planning. The forgectl command
+// exposes it as the "load" subcommand.
+package forgeload
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	forge "github.com/centrixsystems/forge-sdk-go"
+)
+
+// Options configures a load run.
+type Options struct {
+	// RPS is the target request rate per second.
+	RPS float64
+	// Duration is how long to generate load.
+	Duration time.Duration
+	// Concurrency caps the number of in-flight requests (default 64).
+	// Ticks that would exceed the cap are counted as dropped rather than
+	// queued, so a saturated cluster shows up in the report.
+	Concurrency int
+}
+
+// Report summarizes a load run.
+type Report struct {
+	// Requests is the number of requests that completed, successfully or not.
+	Requests int
+	// Errors is the number of failed requests.
+	Errors int
+	// Dropped is the number of ticks skipped because Concurrency was reached.
+	Dropped int
+	// ErrorKinds counts failures by kind, e.g. "connection" or "http_503".
+	ErrorKinds map[string]int
+	// Elapsed is the wall time of the run, including draining in-flight requests.
+	Elapsed time.Duration
+
+	P50, P90, P95, P99, Max time.Duration
+	Mean                    time.Duration
+}
+
+// ErrorRate returns the fraction of completed requests that failed.
+func (r *Report) ErrorRate() float64 {
+	if r.Requests == 0 {
+		return 0
+	}
+	return float64(r.Errors) / float64(r.Requests)
+}
+
+// AchievedRPS returns the completed request rate over the run.
+func (r *Report) AchievedRPS() float64 {
+	if r.Elapsed <= 0 {
+		return 0
+	}
+	return float64(r.Requests) / r.Elapsed.Seconds()
+}
+
+// WriteTo writes a human-readable summary of the report.
+func (r *Report) WriteTo(w io.Writer) (int64, error) {
+	kinds := make([]string, 0, len(r.ErrorKinds))
+	for k := range r.ErrorKinds {
+		kinds = append(kinds, k)
+	}
+	sort.Strings(kinds)
+
+	var n int64
+	write := func(format string, args ...any) error {
+		m, err := fmt.Fprintf(w, format, args...)
+		n += int64(m)
+		return err
+	}
+	if err := write("requests   %d (%.1f/s over %s)\n", r.Requests, r.AchievedRPS(), r.Elapsed.Round(time.Millisecond)); err != nil {
+		return n, err
+	}
+	if err := write("errors     %d (%.2f%%)\n", r.Errors, 100*r.ErrorRate()); err != nil {
+		return n, err
+	}
+	for _, k := range kinds {
+		if err := write("  %-16s %d\n", k, r.ErrorKinds[k]); err != nil {
+			return n, err
+		}
+	}
+	if err := write("dropped    %d\n", r.Dropped); err != nil {
+		return n, err
+	}
+	err := write("latency    mean %s  p50 %s  p90 %s  p95 %s  p99 %s  max %s\n",
+		r.Mean, r.P50, r.P90, r.P95, r.P99, r.Max)
+	return n, err
+}
+
+// Run sends requests from corpus round-robin at opts.RPS until opts.Duration
+// elapses or ctx is cancelled, then waits for in-flight requests to finish.
+func Run(ctx context.Context, corpus []*forge.RenderRequest, opts Options) (*Report, error) {
+	if len(corpus) == 0 {
+		return nil, errors.New("forgeload: empty corpus")
+	}
+	if opts.RPS <= 0 {
+		return nil, errors.New("forgeload: RPS must be positive")
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 64
+	}
+
+	runCtx, cancel := context.WithTimeout(ctx, opts.Duration)
+	defer cancel()
+
+	var (
+		mu        sync.Mutex
+		wg        sync.WaitGroup
+		latencies []time.Duration
+		rep       = &Report{ErrorKinds: map[string]int{}}
+		sem       = make(chan struct{}, opts.Concurrency)
+	)
+
+	start := time.Now()
+	ticker := time.NewTicker(time.Duration(float64(time.Second) / opts.RPS))
+	defer ticker.Stop()
+
+	for i := 0; ; i++ {
+		select {
+		case sem <- struct{}{}:
+			wg.Add(1)
+			go func(req *forge.RenderRequest) {
+				defer wg.Done()
+				defer func() { <-sem }()
+				t0 := time.Now()
+				_, err := req.Send(ctx)
+				d := time.Since(t0)
+
+				mu.Lock()
+				defer mu.Unlock()
+				rep.Requests++
+				latencies = append(latencies, d)
+				if err != nil {
+					rep.Errors++
+					rep.ErrorKinds[errorKind(err)]++
+				}
+			}(corpus[i%len(corpus)])
+		default:
+			mu.Lock()
+			rep.Dropped++
+			mu.Unlock()
+		}
+
+		select {
+		case <-ticker.C:
+		case <-runCtx.Done():
+			wg.Wait()
+			rep.Elapsed = time.Since(start)
+			summarize(rep, latencies)
+			return rep, nil
+		}
+	}
+}
+
+// errorKind classifies a render error for the report.
+func errorKind(err error) string {
+	var se *forge.ServerError
+	var ce *forge.ConnectionError
+	switch {
+	case errors.As(err, &se):
+		return fmt.Sprintf("http_%d", se.StatusCode)
+	case errors.As(err, &ce):
+		return "connection"
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return "canceled"
+	default:
+		return "other"
+	}
+}
+
+// summarize fills the latency fields of rep.
+func summarize(rep *Report, latencies []time.Duration) {
+	if len(latencies) == 0 {
+		return
+	}
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+
+	var total time.Duration
+	for _, d := range latencies {
+		total += d
+	}
+	rep.Mean = total / time.Duration(len(latencies))
+	rep.P50 = percentile(latencies, 50)
+	rep.P90 = percentile(latencies, 90)
+	rep.P95 = percentile(latencies, 95)
+	rep.P99 = percentile(latencies, 99)
+	rep.Max = latencies[len(latencies)-1]
+}
+
+// percentile returns the nearest-rank percentile of sorted.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// LoadCorpus reads every *.json file in dir as a forge.RenderPayload and
+// returns the corresponding requests bound to c, in file name order.
+func LoadCorpus(c *forge.Client, dir string) ([]*forge.RenderRequest, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	corpus := make([]*forge.RenderRequest, 0, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var p forge.RenderPayload
+		if err := json.Unmarshal(data, &p); err != nil {
+			return nil, fmt.Errorf("forgeload: %s: %w", path, err)
+		}
+		corpus = append(corpus, c.FromPayload(&p))
+	}
+	return corpus, nil
+}
@@ -0,0 +1,85 @@
+package forgeload
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	forge "github.com/centrixsystems/forge-sdk-go"
+)
+
+func TestRun(t *testing.T) {
+	var n atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if n.Add(1)%2 == 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("%PDF"))
+	}))
+	defer srv.Close()
+
+	c := forge.NewClient(srv.URL)
+	corpus := []*forge.RenderRequest{c.RenderHTML("<p>a</p>"), c.RenderHTML("<p>b</p>")}
+	rep, err := Run(context.Background(), corpus, Options{RPS: 200, Duration: 100 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rep.Requests == 0 {
+		t.Fatal("no requests completed")
+	}
+	if rep.Errors != rep.ErrorKinds["http_503"] {
+		t.Errorf("errors = %d, kinds = %v", rep.Errors, rep.ErrorKinds)
+	}
+	if rep.Errors == 0 || rep.ErrorRate() <= 0 || rep.ErrorRate() >= 1 {
+		t.Errorf("error rate = %v", rep.ErrorRate())
+	}
+	if rep.P50 > rep.P99 || rep.P99 > rep.Max {
+		t.Errorf("percentiles out of order: p50 %v p99 %v max %v", rep.P50, rep.P99, rep.Max)
+	}
+}
+
+func TestRunRejectsEmptyCorpus(t *testing.T) {
+	if _, err := Run(context.Background(), nil, Options{RPS: 1, Duration: time.Second}); err == nil {
+		t.Error("expected error for empty corpus")
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	sorted := make([]time.Duration, 100)
+	for i := range sorted {
+		sorted[i] = time.Duration(i+1) * time.Millisecond
+	}
+	if got := percentile(sorted, 50); got != 50*time.Millisecond {
+		t.Errorf("p50 = %v", got)
+	}
+	if got := percentile(sorted, 99); got != 99*time.Millisecond {
+		t.Errorf("p99 = %v", got)
+	}
+}
+
+func TestLoadCorpus(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "b.json"), []byte(`{"url":"https://example.com","format":"png"}`), 0o644)
+	os.WriteFile(filepath.Join(dir, "a.json"), []byte(`{"html":"<h1>A</h1>","format":"pdf"}`), 0o644)
+	os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("ignored"), 0o644)
+
+	corpus, err := LoadCorpus(forge.NewClient("http://localhost:3000"), dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(corpus) != 2 {
+		t.Fatalf("corpus len = %d, want 2", len(corpus))
+	}
+	if p := corpus[0].Payload(); p.HTML == nil || *p.HTML != "<h1>A</h1>" {
+		t.Errorf("first payload = %+v", p)
+	}
+	if p := corpus[1].Payload(); p.Format != forge.FormatPNG {
+		t.Errorf("second format = %v", p.Format)
+	}
+}
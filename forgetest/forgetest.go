@@ -0,0 +1,198 @@
+// Package forgetest provides test doubles for code that uses the Forge SDK.
+//
+// StrictServer is a fake Forge server that checks every render request
+// against the published request schema and fails the test on unknown or
+// malformed fields, so payload regressions such as a misspelled JSON key
+// are caught before they reach a real server.
+package forgetest
+
+import (
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// StrictServer is a fake Forge server that validates render payloads, sent
+// either as JSON or as multipart/form-data.
+// Valid requests get a 200 response with Response as the body; invalid
+// ones fail the test and get a 422 with a "schema_violation" error code.
+type StrictServer struct {
+	*httptest.Server
+
+	// Response is the body returned for valid render requests.
+	Response []byte
+
+	t        testing.TB
+	mu       sync.Mutex
+	payloads []map[string]any
+}
+
+// NewStrictServer starts a StrictServer that reports violations to t and
+// is closed when the test ends.
+func NewStrictServer(t testing.TB) *StrictServer {
+	t.Helper()
+	s := &StrictServer{t: t, Response: []byte("%PDF-1.7\n%forgetest\n")}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// Payloads returns the decoded bodies of the render requests received so
+// far, valid or not.
+func (s *StrictServer) Payloads() []map[string]any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]map[string]any(nil), s.payloads...)
+}
+
+func (s *StrictServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/health":
+		w.WriteHeader(http.StatusOK)
+	case r.Method == http.MethodPost && (r.URL.Path == "/render" || r.URL.Path == "/render/archive"):
+		s.render(w, r)
+	default:
+		s.t.Errorf("forgetest: unexpected request %s %s", r.Method, r.URL.Path)
+		http.NotFound(w, r)
+	}
+}
+
+func (s *StrictServer) render(w http.ResponseWriter, r *http.Request) {
+	var body io.Reader = r.Body
+	if r.Header.Get("Content-Encoding") == "gzip" {
+		zr, err := gzip.NewReader(r.Body)
+		if err != nil {
+			s.reject(w, fmt.Sprintf("invalid gzip body: %v", err))
+			return
+		}
+		body = zr
+	}
+	var payload map[string]any
+	ct := r.Header.Get("Content-Type")
+	switch mediaType, params, _ := mime.ParseMediaType(ct); mediaType {
+	case "application/json":
+		if err := json.NewDecoder(body).Decode(&payload); err != nil {
+			s.reject(w, fmt.Sprintf("invalid JSON body: %v", err))
+			return
+		}
+	case "multipart/form-data":
+		var err error
+		if payload, err = readMultipart(multipart.NewReader(body, params["boundary"])); err != nil {
+			s.reject(w, err.Error())
+			return
+		}
+	default:
+		s.reject(w, fmt.Sprintf("Content-Type %q, want application/json or multipart/form-data", ct))
+		return
+	}
+	s.mu.Lock()
+	s.payloads = append(s.payloads, payload)
+	s.mu.Unlock()
+
+	if errs := Validate(payload); len(errs) > 0 {
+		s.reject(w, strings.Join(errs, "; "))
+		return
+	}
+	_, hasHTML := payload["html"]
+	_, hasURL := payload["url"]
+	_, hasTemplate := payload["template"]
+	archive, hasArchive := payload["archive"].(map[string]any)
+	switch {
+	case r.URL.Path == "/render/archive":
+		if !hasArchive || archive["data"] == nil {
+			s.reject(w, "archive with data is required")
+			return
+		}
+	case hasArchive:
+		s.reject(w, "archive payloads must be sent to /render/archive")
+		return
+	case !hasHTML && !hasURL && !hasTemplate:
+		s.reject(w, "one of html, url, or template is required")
+		return
+	}
+	// Response is the same for every format, so do not claim one.
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Write(s.Response)
+}
+
+// readMultipart decodes a multipart render request into the equivalent JSON
+// payload, with each "assets" part restored as a base64 assets entry and
+// an "archive" part as the base64 archive data.
+func readMultipart(mr *multipart.Reader) (map[string]any, error) {
+	var payload map[string]any
+	var assets []any
+	var archive []byte
+	for {
+		p, err := mr.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid multipart body: %v", err)
+		}
+		// Part.FileName strips directories, so read the parameter directly.
+		_, disp, err := mime.ParseMediaType(p.Header.Get("Content-Disposition"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid Content-Disposition: %v", err)
+		}
+		switch disp["name"] {
+		case "payload":
+			if payload != nil {
+				return nil, errors.New("duplicate payload part")
+			}
+			if err := json.NewDecoder(p).Decode(&payload); err != nil {
+				return nil, fmt.Errorf("invalid JSON payload part: %v", err)
+			}
+		case "assets":
+			data, err := io.ReadAll(p)
+			if err != nil {
+				return nil, fmt.Errorf("invalid multipart body: %v", err)
+			}
+			assets = append(assets, map[string]any{
+				"name":      disp["filename"],
+				"mime_type": p.Header.Get("Content-Type"),
+				"data":      base64.StdEncoding.EncodeToString(data),
+			})
+		case "archive":
+			if archive, err = io.ReadAll(p); err != nil {
+				return nil, fmt.Errorf("invalid multipart body: %v", err)
+			}
+		default:
+			return nil, fmt.Errorf("unexpected multipart part %q", disp["name"])
+		}
+	}
+	if payload == nil {
+		return nil, errors.New("multipart body has no payload part")
+	}
+	if assets != nil {
+		if _, ok := payload["assets"]; ok {
+			return nil, errors.New("assets sent both in the payload and as parts")
+		}
+		payload["assets"] = assets
+	}
+	if archive != nil {
+		a, ok := payload["archive"].(map[string]any)
+		if !ok || a["data"] != nil {
+			return nil, errors.New("archive part must match an archive payload without data")
+		}
+		a["data"] = base64.StdEncoding.EncodeToString(archive)
+	}
+	return payload, nil
+}
+
+func (s *StrictServer) reject(w http.ResponseWriter, msg string) {
+	s.t.Errorf("forgetest: invalid render request: %s", msg)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusUnprocessableEntity)
+	json.NewEncoder(w).Encode(map[string]string{"error": msg, "code": "schema_violation"})
+}
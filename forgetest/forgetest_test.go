@@ -0,0 +1,168 @@
+package forgetest
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	forge "github.com/centrixsystems/forge-sdk-go"
+)
+
+func TestStrictServerAcceptsSDKPayloads(t *testing.T) {
+	srv := NewStrictServer(t)
+	c := forge.NewClient(srv.URL, forge.WithCompression(64))
+	reqs := []*forge.RenderRequest{
+		c.RenderHTML("<h1>Invoice</h1>").
+			Paper(forge.PaperA4).
+			MarginsAll(10, forge.UnitMM).
+			PdfTitle("Invoice").
+			PdfPageNumbering(forge.PageNumbering{Start: 3, SkipFirst: true}).
+			PdfWatermarkText("COPY").
+			PdfBarcode(forge.BarcodeQR, "https://example.com").
+			PdfAttach("data.xml", "PGRhdGEvPg==").
+			PdfSignCertificate("Y2VydA==").
+			PdfUserPassword("pw").
+			PdfAccessibility(forge.AccessibilityPdfUa1).
+			PdfLang("en-US").
+			PageOrientationOverrides(map[string]forge.Orientation{"3-4": forge.Landscape}),
+		c.RenderURL("https://example.com").
+			Format(forge.FormatPNG).
+			Width(1280).
+			Transparent(true).
+			CaptureSelector("#chart").
+			PngIndexed(true).
+			CustomPalette([]string{"#000000", "#ffffff"}),
+		c.RenderHTML("x").Format(forge.FormatTIFF).TiffMultipage(true).Flow(forge.FlowHybrid).MaxPageHeight(5000),
+		c.RenderHTML("x").EmailMode(true).IncludeTextLayout(true),
+		c.RenderHTML(`<img src="img/logo.png">`).Asset("img/logo.png", []byte("\x89PNG"), "image/png").DocumentLocale("de-CH"),
+	}
+	for _, r := range reqs {
+		if _, err := r.Send(context.Background()); err != nil {
+			t.Error(err)
+		}
+	}
+	if n := len(srv.Payloads()); n != len(reqs) {
+		t.Errorf("Payloads() = %d, want %d", n, len(reqs))
+	}
+}
+
+func TestStrictServerAcceptsMultipart(t *testing.T) {
+	srv := NewStrictServer(t)
+	c := forge.NewClient(srv.URL, forge.WithMultipartThreshold(0))
+	_, err := c.RenderHTML(`<link rel="stylesheet" href="css/a.css">`).
+		Asset("css/a.css", []byte("p{}"), "text/css").
+		Send(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	assets := srv.Payloads()[0]["assets"].([]any)
+	want := map[string]any{"name": "css/a.css", "mime_type": "text/css", "data": "cHt9"}
+	if len(assets) != 1 || !reflect.DeepEqual(assets[0], want) {
+		t.Errorf("assets = %v, want [%v]", assets, want)
+	}
+}
+
+func TestStrictServerAcceptsArchives(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "index.html"), []byte("<h1>Site</h1>"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	srv := NewStrictServer(t)
+	for _, threshold := range []int{-1, 0} {
+		c := forge.NewClient(srv.URL, forge.WithMultipartThreshold(threshold))
+		if _, err := c.RenderDir(dir, "index.html").Send(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, p := range srv.Payloads() {
+		if a := p["archive"].(map[string]any); a["entry"] != "index.html" || a["data"] == nil {
+			t.Errorf("archive = %v", a)
+		}
+	}
+}
+
+type recorder struct {
+	testing.TB
+	errs []string
+}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errs = append(r.errs, fmt.Sprintf(format, args...))
+}
+
+func TestStrictServerRejectsUnknownFields(t *testing.T) {
+	rec := &recorder{TB: t}
+	srv := NewStrictServer(rec)
+	c := forge.NewClient(srv.URL)
+	_, err := c.FromPayload(&forge.RenderPayload{}).Send(context.Background())
+	if se, ok := err.(*forge.ServerError); !ok || se.Code != "schema_violation" {
+		t.Errorf("err = %v, want schema_violation *ServerError", err)
+	}
+	if len(rec.errs) != 1 {
+		t.Errorf("reported %d errors, want 1: %v", len(rec.errs), rec.errs)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	errs := Validate(map[string]any{
+		"html":     "x",
+		"formt":    "pdf",
+		"width":    12.5,
+		"quantize": map[string]any{"colors": 300.0},
+		"pdf": map[string]any{
+			"barcodes":         []any{map[string]any{"type": "qr"}},
+			"bookmark_outline": map[string]any{"selectors": map[string]any{"7": "h1"}},
+		},
+	})
+	want := []string{
+		"$.formt: unknown field",
+		"$.pdf.barcodes[0]: missing required field \"data\"",
+		"$.pdf.bookmark_outline.selectors.7: 7 is not one of [1 2 3 4 5 6]",
+		"$.quantize.colors: 300 is above the maximum 256",
+		"$.width: got number, want integer",
+	}
+	if strings.Join(errs, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Validate =\n%s\nwant\n%s", strings.Join(errs, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+// TestSchemaCoversPayload fails when a RenderPayload field is added without
+// updating schema.json.
+func TestSchemaCoversPayload(t *testing.T) {
+	var walk func(path string, typ reflect.Type, s *schema)
+	walk = func(path string, typ reflect.Type, s *schema) {
+		if s.Ref != "" {
+			s = renderSchema.Defs[strings.TrimPrefix(s.Ref, "#/$defs/")]
+		}
+		for typ.Kind() == reflect.Pointer || typ.Kind() == reflect.Slice {
+			typ = typ.Elem()
+			if s.Items != nil {
+				s = s.Items
+				if s.Ref != "" {
+					s = renderSchema.Defs[strings.TrimPrefix(s.Ref, "#/$defs/")]
+				}
+			}
+		}
+		if typ.Kind() != reflect.Struct {
+			return
+		}
+		for i := 0; i < typ.NumField(); i++ {
+			f := typ.Field(i)
+			name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+			if name == "" || name == "-" {
+				continue
+			}
+			ps, ok := s.Properties[name]
+			if !ok {
+				t.Errorf("schema.json lacks %s.%s", path, name)
+				continue
+			}
+			walk(path+"."+name, f.Type, ps)
+		}
+	}
+	walk("$", reflect.TypeOf(forge.RenderPayload{}), renderSchema)
+}
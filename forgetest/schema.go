@@ -0,0 +1,212 @@
+package forgetest
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Schema is the published JSON Schema of the render request body.
+//
+//go:embed schema.json
+var Schema []byte
+
+// schema is the subset of JSON Schema the render schema uses.
+type schema struct {
+	Ref                  string             `json:"$ref"`
+	Type                 typeList           `json:"type"`
+	Enum                 []any              `json:"enum"`
+	Properties           map[string]*schema `json:"properties"`
+	AdditionalProperties *additional        `json:"additionalProperties"`
+	PropertyNames        *schema            `json:"propertyNames"`
+	Required             []string           `json:"required"`
+	Items                *schema            `json:"items"`
+	Minimum              *float64           `json:"minimum"`
+	Maximum              *float64           `json:"maximum"`
+	Defs                 map[string]*schema `json:"$defs"`
+}
+
+// typeList is a JSON Schema "type": a single name or a list of names.
+type typeList []string
+
+func (t *typeList) UnmarshalJSON(data []byte) error {
+	var one string
+	if json.Unmarshal(data, &one) == nil {
+		*t = typeList{one}
+		return nil
+	}
+	var many []string
+	if err := json.Unmarshal(data, &many); err != nil {
+		return err
+	}
+	*t = many
+	return nil
+}
+
+// additional is a JSON Schema "additionalProperties": false, true, or a
+// schema that unlisted properties must match.
+type additional struct {
+	forbidden bool
+	schema    *schema
+}
+
+func (a *additional) UnmarshalJSON(data []byte) error {
+	var allowed bool
+	if json.Unmarshal(data, &allowed) == nil {
+		a.forbidden = !allowed
+		return nil
+	}
+	return json.Unmarshal(data, &a.schema)
+}
+
+var renderSchema = mustParseSchema(Schema)
+
+func mustParseSchema(data []byte) *schema {
+	var s schema
+	if err := json.Unmarshal(data, &s); err != nil {
+		panic("forgetest: invalid embedded schema: " + err.Error())
+	}
+	return &s
+}
+
+// Validate checks a decoded JSON render payload against the schema and
+// returns one message per violation, sorted by path.
+func Validate(payload any) []string {
+	v := validator{root: renderSchema}
+	v.check("$", renderSchema, payload)
+	sort.Strings(v.errs)
+	return v.errs
+}
+
+type validator struct {
+	root *schema
+	errs []string
+}
+
+func (v *validator) errorf(path, format string, args ...any) {
+	v.errs = append(v.errs, path+": "+fmt.Sprintf(format, args...))
+}
+
+func (v *validator) check(path string, s *schema, val any) {
+	if s.Ref != "" {
+		name := strings.TrimPrefix(s.Ref, "#/$defs/")
+		def, ok := v.root.Defs[name]
+		if !ok {
+			v.errorf(path, "schema references unknown definition %q", s.Ref)
+			return
+		}
+		s = def
+	}
+
+	if len(s.Type) > 0 && !matchesType(s.Type, val) {
+		v.errorf(path, "got %s, want %s", jsonType(val), strings.Join(s.Type, " or "))
+		return
+	}
+	if len(s.Enum) > 0 && !inEnum(s.Enum, val) {
+		v.errorf(path, "%v is not one of %v", val, s.Enum)
+		return
+	}
+
+	switch val := val.(type) {
+	case map[string]any:
+		for _, name := range s.Required {
+			if _, ok := val[name]; !ok {
+				v.errorf(path, "missing required field %q", name)
+			}
+		}
+		for name, fv := range val {
+			if s.PropertyNames != nil {
+				v.check(path+"."+name, s.PropertyNames, name)
+			}
+			ps, ok := s.Properties[name]
+			if !ok && s.AdditionalProperties != nil {
+				if s.AdditionalProperties.forbidden {
+					v.errorf(path+"."+name, "unknown field")
+				}
+				ps = s.AdditionalProperties.schema
+			}
+			if ps != nil {
+				v.check(path+"."+name, ps, fv)
+			}
+		}
+	case []any:
+		if s.Items != nil {
+			for i, item := range val {
+				v.check(fmt.Sprintf("%s[%d]", path, i), s.Items, item)
+			}
+		}
+	case float64:
+		if s.Minimum != nil && val < *s.Minimum {
+			v.errorf(path, "%v is below the minimum %v", val, *s.Minimum)
+		}
+		if s.Maximum != nil && val > *s.Maximum {
+			v.errorf(path, "%v is above the maximum %v", val, *s.Maximum)
+		}
+	}
+}
+
+func matchesType(types []string, val any) bool {
+	for _, t := range types {
+		switch t {
+		case "object":
+			if _, ok := val.(map[string]any); ok {
+				return true
+			}
+		case "array":
+			if _, ok := val.([]any); ok {
+				return true
+			}
+		case "string":
+			if _, ok := val.(string); ok {
+				return true
+			}
+		case "boolean":
+			if _, ok := val.(bool); ok {
+				return true
+			}
+		case "number":
+			if _, ok := val.(float64); ok {
+				return true
+			}
+		case "integer":
+			if f, ok := val.(float64); ok && f == math.Trunc(f) {
+				return true
+			}
+		case "null":
+			if val == nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func inEnum(enum []any, val any) bool {
+	for _, e := range enum {
+		if e == val {
+			return true
+		}
+	}
+	return false
+}
+
+func jsonType(val any) string {
+	switch val.(type) {
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case nil:
+		return "null"
+	}
+	return fmt.Sprintf("%T", val)
+}
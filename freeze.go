@@ -0,0 +1,222 @@
+package forge
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+)
+
+// clonePayload returns a deep copy of p, so that the copy and p can be
+// modified independently. Byte slices, such as asset data, are shared:
+// they are never modified once added to a payload.
+func clonePayload(p *RenderPayload) *RenderPayload {
+	return deepCopy(reflect.ValueOf(p)).Interface().(*RenderPayload)
+}
+
+func deepCopy(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Elem().Type())
+		c.Elem().Set(deepCopy(v.Elem()))
+		return c
+	case reflect.Struct:
+		c := reflect.New(v.Type()).Elem()
+		c.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if c.Field(i).CanSet() {
+				c.Field(i).Set(deepCopy(v.Field(i)))
+			}
+		}
+		return c
+	case reflect.Slice:
+		if v.IsNil() || v.Type().Elem().Kind() == reflect.Uint8 {
+			return v
+		}
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(deepCopy(v.Index(i)))
+		}
+		return c
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeMapWithSize(v.Type(), v.Len())
+		for it := v.MapRange(); it.Next(); {
+			c.SetMapIndex(it.Key(), deepCopy(it.Value()))
+		}
+		return c
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Type()).Elem()
+		c.Set(deepCopy(v.Elem()))
+		return c
+	}
+	return v
+}
+
+// Clone returns an independent copy of the request. Builder calls on the
+// clone do not affect the original, so a base request can be cloned and
+// customized per goroutine.
+func (r *RenderRequest) Clone() *RenderRequest {
+	c := *r
+	c.p = *clonePayload(&r.p)
+	return &c
+}
+
+// compiled is a validated snapshot of a render request, ready to send.
+type compiled struct {
+	client *Client
+	p      *RenderPayload
+	accept string
+	via    string
+
+	// body and contentType cache the encoded payload, if set.
+	body        []byte
+	contentType string
+}
+
+// compile validates the request and snapshots its payload. Later builder
+// calls do not affect the snapshot.
+func (r *RenderRequest) compile() (*compiled, error) {
+	if err := r.validate(); err != nil {
+		return nil, err
+	}
+	return &compiled{client: r.client, p: r.Payload(), accept: r.accept, via: r.via}, nil
+}
+
+// send executes the render request, journaling it if the client has a
+// journal.
+func (cr *compiled) send(ctx context.Context) (*http.Response, *exchange, error) {
+	c := cr.client
+	c.warnDeprecated(cr.p)
+	var entry string
+	if j := c.journal; j != nil {
+		var err error
+		if entry, err = j.write(cr.p, cr.accept, cr.via); err != nil {
+			return nil, nil, fmt.Errorf("forge: journal: %w", err)
+		}
+	}
+	resp, x, err := cr.post(ctx)
+	if entry != "" && !isOutage(resp, err) {
+		c.journal.remove(entry)
+	}
+	return resp, x, err
+}
+
+// post encodes the payload and posts it to the render endpoint, bounding the render
+// by ctx's deadline.
+func (cr *compiled) post(ctx context.Context) (*http.Response, *exchange, error) {
+	c := cr.client
+	body, contentType := cr.body, cr.contentType
+	budget, bounded := c.renderBudget(ctx)
+	if bounded || body == nil {
+		p := cr.p
+		if bounded {
+			var err error
+			if p, err = applyBudget(p, budget); err != nil {
+				return nil, nil, err
+			}
+		}
+		var err error
+		if body, contentType, err = c.encodePayload(p); err != nil {
+			return nil, nil, fmt.Errorf("forge: marshal error: %w", err)
+		}
+	}
+
+	base := c.baseURL
+	if cr.via != "" {
+		base = cr.via
+	}
+	req, err := c.newRequestAt(ctx, base, http.MethodPost, renderPath(cr.p), body, contentType)
+	if err != nil {
+		return nil, nil, fmt.Errorf("forge: request error: %w", err)
+	}
+	if cr.accept != "" {
+		req.Header.Set("Accept", cr.accept)
+	}
+	if bounded {
+		req.Header.Set("X-Forge-Timeout", budgetHeader(budget))
+	}
+
+	return c.do(req)
+}
+
+// sendWithWarnings executes the render request and reads the response.
+func (cr *compiled) sendWithWarnings(ctx context.Context) (*RenderResponse, error) {
+	resp, x, err := cr.send(ctx)
+	if err != nil {
+		return nil, err
+	}
+	data, err := readResponse(resp, x)
+	if err != nil {
+		return nil, err
+	}
+
+	res := newRenderResponse(resp.Header, data, x)
+	if res.Locale == "" && cr.p.Locale != nil {
+		res.Locale = *cr.p.Locale
+	}
+	parts, err := splitMultipart(resp.Header.Get("Content-Type"), data)
+	if err != nil {
+		return nil, err
+	}
+	if parts != nil {
+		if err := res.applyParts(parts); err != nil {
+			return nil, err
+		}
+	} else if err := checkContentType(cr.p, resp.Header, data); err != nil {
+		return nil, err
+	}
+	cr.client.warnings.add(res.Warnings)
+	if err := cr.client.transform(ctx, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// FrozenRequest is an immutable, validated render request with its
+// payload encoded once, for hot paths that send the same request
+// repeatedly. It is safe for concurrent use. Create one with Frozen.
+type FrozenRequest struct {
+	c *compiled
+}
+
+// Frozen validates the request and compiles it into a FrozenRequest.
+// Later builder calls on r do not affect it.
+func (r *RenderRequest) Frozen() (*FrozenRequest, error) {
+	c, err := r.compile()
+	if err != nil {
+		return nil, err
+	}
+	if c.body, c.contentType, err = c.client.encodePayload(c.p); err != nil {
+		return nil, fmt.Errorf("forge: marshal error: %w", err)
+	}
+	return &FrozenRequest{c: c}, nil
+}
+
+// Payload returns a copy of the payload the request sends.
+func (f *FrozenRequest) Payload() *RenderPayload {
+	return clonePayload(f.c.p)
+}
+
+// Send executes the render request and returns the raw output bytes.
+func (f *FrozenRequest) Send(ctx context.Context) ([]byte, error) {
+	res, err := f.SendWithWarnings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return res.Data, nil
+}
+
+// SendWithWarnings executes the render request and returns the full
+// response.
+func (f *FrozenRequest) SendWithWarnings(ctx context.Context) (*RenderResponse, error) {
+	return f.c.sendWithWarnings(ctx)
+}
@@ -0,0 +1,244 @@
+package forge
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ImageOptions holds encoder settings for image output formats. Each
+// setting applies only to its own format.
+type ImageOptions struct {
+	JpegQuality     *int             `json:"jpeg_quality,omitempty"`
+	JpegSubsampling *JpegSubsampling `json:"jpeg_subsampling,omitempty"`
+	PngCompression  *int             `json:"png_compression,omitempty"`
+	PngBitDepth     *int             `json:"png_bit_depth,omitempty"`
+	PngIndexed      *bool            `json:"png_indexed,omitempty"`
+	WebpQuality     *int             `json:"webp_quality,omitempty"`
+	WebpLossless    *bool            `json:"webp_lossless,omitempty"`
+	TiffCompression *TiffCompression `json:"tiff_compression,omitempty"`
+	TiffMultipage   *bool            `json:"tiff_multipage,omitempty"`
+	AvifQuality     *int             `json:"avif_quality,omitempty"`
+	AvifSpeed       *int             `json:"avif_speed,omitempty"`
+	// FrameDelay is the time each page is shown in animated output, in
+	// milliseconds.
+	FrameDelay *int `json:"frame_delay,omitempty"`
+}
+
+// JpegSubsampling specifies JPEG chroma subsampling.
+type JpegSubsampling string
+
+const (
+	// JpegSubsampling444 keeps full color resolution (sharpest text, largest files).
+	JpegSubsampling444 JpegSubsampling = "4:4:4"
+	// JpegSubsampling422 halves horizontal color resolution.
+	JpegSubsampling422 JpegSubsampling = "4:2:2"
+	// JpegSubsampling420 halves horizontal and vertical color resolution.
+	JpegSubsampling420 JpegSubsampling = "4:2:0"
+)
+
+// TiffCompression specifies the TIFF compression scheme.
+type TiffCompression string
+
+const (
+	TiffCompressionNone TiffCompression = "none"
+	TiffCompressionLZW  TiffCompression = "lzw"
+	TiffCompressionZIP  TiffCompression = "zip"
+	// TiffCompressionG4 is CCITT Group 4 fax compression. It stores 1-bit
+	// black-and-white pages only.
+	TiffCompressionG4 TiffCompression = "ccitt-g4"
+)
+
+// image returns the request's image options, creating them if needed.
+func (r *RenderRequest) image() *ImageOptions {
+	if r.p.Image == nil {
+		r.p.Image = &ImageOptions{}
+	}
+	return r.p.Image
+}
+
+// JpegQuality sets the JPEG encoder quality (1-100).
+func (r *RenderRequest) JpegQuality(q int) *RenderRequest {
+	if q < 1 || q > 100 {
+		r.fail("image.jpeg_quality", "must be between 1 and 100, got %d", q)
+		return r
+	}
+	r.image().JpegQuality = &q
+	return r
+}
+
+// JpegSubsampling sets the JPEG chroma subsampling.
+func (r *RenderRequest) JpegSubsampling(s JpegSubsampling) *RenderRequest {
+	switch s {
+	case JpegSubsampling444, JpegSubsampling422, JpegSubsampling420:
+	default:
+		r.fail("image.jpeg_subsampling", "unknown subsampling %q", s)
+		return r
+	}
+	r.image().JpegSubsampling = &s
+	return r
+}
+
+// PngCompression sets the PNG zlib compression level, from 0 (none,
+// fastest) to 9 (smallest).
+func (r *RenderRequest) PngCompression(level int) *RenderRequest {
+	if level < 0 || level > 9 {
+		r.fail("image.png_compression", "must be between 0 and 9, got %d", level)
+		return r
+	}
+	r.image().PngCompression = &level
+	return r
+}
+
+// PngBitDepth sets the PNG bits per channel, 8 or 16.
+func (r *RenderRequest) PngBitDepth(bits int) *RenderRequest {
+	if bits != 8 && bits != 16 {
+		r.fail("image.png_bit_depth", "must be 8 or 16, got %d", bits)
+		return r
+	}
+	r.image().PngBitDepth = &bits
+	return r
+}
+
+// PngIndexed writes a palette-based PNG, much smaller for flat-colored
+// output. Combine with Colors or Palette to control the palette.
+func (r *RenderRequest) PngIndexed(enabled bool) *RenderRequest {
+	r.image().PngIndexed = &enabled
+	return r
+}
+
+// WebpQuality sets the WebP encoder quality (0-100). With WebpLossless it
+// trades encoding time for size instead of fidelity.
+func (r *RenderRequest) WebpQuality(q int) *RenderRequest {
+	if q < 0 || q > 100 {
+		r.fail("image.webp_quality", "must be between 0 and 100, got %d", q)
+		return r
+	}
+	r.image().WebpQuality = &q
+	return r
+}
+
+// WebpLossless enables lossless WebP encoding.
+func (r *RenderRequest) WebpLossless(enabled bool) *RenderRequest {
+	r.image().WebpLossless = &enabled
+	return r
+}
+
+// TiffCompression sets the TIFF compression scheme.
+func (r *RenderRequest) TiffCompression(c TiffCompression) *RenderRequest {
+	switch c {
+	case TiffCompressionNone, TiffCompressionLZW, TiffCompressionZIP, TiffCompressionG4:
+	default:
+		r.fail("image.tiff_compression", "unknown compression %q", c)
+		return r
+	}
+	r.image().TiffCompression = &c
+	return r
+}
+
+// TiffMultipage renders every page into a single multi-page TIFF instead
+// of only the first page.
+func (r *RenderRequest) TiffMultipage(enabled bool) *RenderRequest {
+	r.image().TiffMultipage = &enabled
+	return r
+}
+
+// AvifQuality sets the AVIF encoder quality (0-100).
+func (r *RenderRequest) AvifQuality(q int) *RenderRequest {
+	if q < 0 || q > 100 {
+		r.fail("image.avif_quality", "must be between 0 and 100, got %d", q)
+		return r
+	}
+	r.image().AvifQuality = &q
+	return r
+}
+
+// AvifSpeed sets the AVIF encoder speed, from 0 (slowest, smallest) to 10
+// (fastest).
+func (r *RenderRequest) AvifSpeed(speed int) *RenderRequest {
+	if speed < 0 || speed > 10 {
+		r.fail("image.avif_speed", "must be between 0 and 10, got %d", speed)
+		return r
+	}
+	r.image().AvifSpeed = &speed
+	return r
+}
+
+// FrameDelay sets how long each page is shown in FormatGIF and FormatAPNG
+// output, where every page becomes one animation frame. GIF stores delays
+// in hundredths of a second, so d is rounded to 10ms there.
+func (r *RenderRequest) FrameDelay(d time.Duration) *RenderRequest {
+	if d < time.Millisecond {
+		r.fail("image.frame_delay", "must be at least 1ms, got %v", d)
+		return r
+	}
+	ms := int(d / time.Millisecond)
+	r.image().FrameDelay = &ms
+	return r
+}
+
+// validateImage rejects encoder settings for a format other than the
+// request's. With FormatAuto any setting is allowed, since the server
+// picks the format.
+func (r *RenderRequest) validateImage() error {
+	img := r.p.Image
+	if img == nil || r.p.Format == FormatAuto {
+		return nil
+	}
+	for _, s := range []struct {
+		set     bool
+		field   string
+		formats []OutputFormat
+	}{
+		{img.JpegQuality != nil, "jpeg_quality", []OutputFormat{FormatJPEG}},
+		{img.JpegSubsampling != nil, "jpeg_subsampling", []OutputFormat{FormatJPEG}},
+		{img.PngCompression != nil, "png_compression", []OutputFormat{FormatPNG}},
+		{img.PngBitDepth != nil, "png_bit_depth", []OutputFormat{FormatPNG}},
+		{img.PngIndexed != nil, "png_indexed", []OutputFormat{FormatPNG}},
+		{img.WebpQuality != nil, "webp_quality", []OutputFormat{FormatWebP}},
+		{img.WebpLossless != nil, "webp_lossless", []OutputFormat{FormatWebP}},
+		{img.TiffCompression != nil, "tiff_compression", []OutputFormat{FormatTIFF}},
+		{img.TiffMultipage != nil, "tiff_multipage", []OutputFormat{FormatTIFF}},
+		{img.AvifQuality != nil, "avif_quality", []OutputFormat{FormatAVIF}},
+		{img.AvifSpeed != nil, "avif_speed", []OutputFormat{FormatAVIF}},
+		{img.FrameDelay != nil, "frame_delay", []OutputFormat{FormatGIF, FormatAPNG}},
+	} {
+		if s.set && !slices.Contains(s.formats, r.p.Format) {
+			return &ValidationError{Field: "image." + s.field, Message: fmt.Sprintf("applies only to %s output", formatList(s.formats))}
+		}
+	}
+	if img.PngIndexed != nil && *img.PngIndexed && img.PngBitDepth != nil && *img.PngBitDepth == 16 {
+		return &ValidationError{Field: "image.png_bit_depth", Message: "indexed PNGs are at most 8-bit"}
+	}
+	if img.TiffCompression != nil && *img.TiffCompression == TiffCompressionG4 && !isBilevel(r.p.Quantize) {
+		return &ValidationError{Field: "image.tiff_compression", Message: "CCITT G4 requires Colors(2) or PaletteBlackWhite"}
+	}
+	return nil
+}
+
+// formatList joins formats for error messages, e.g. "gif or apng".
+func formatList(formats []OutputFormat) string {
+	names := make([]string, len(formats))
+	for i, f := range formats {
+		names[i] = string(f)
+	}
+	return strings.Join(names, " or ")
+}
+
+// isBilevel reports whether q reduces output to two colors.
+func isBilevel(q *QuantizeOptions) bool {
+	if q == nil {
+		return false
+	}
+	if q.Colors != nil && *q.Colors == 2 {
+		return true
+	}
+	switch p := q.Palette.(type) {
+	case string:
+		return p == string(PaletteBlackWhite)
+	case Palette:
+		return p == PaletteBlackWhite
+	}
+	return false
+}
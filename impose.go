@@ -0,0 +1,75 @@
+package forge
+
+import (
+	"context"
+	"fmt"
+)
+
+// ImpositionLayout is how pages are arranged on printed sheets.
+type ImpositionLayout string
+
+const (
+	ImpositionNUp     ImpositionLayout = "n-up"
+	ImpositionBooklet ImpositionLayout = "booklet"
+)
+
+// Imposition arranges document pages on sheets for printing. Use NUp or
+// Booklet.
+type Imposition struct {
+	Layout ImpositionLayout `json:"layout"`
+	// Pages is the number of pages per sheet side, for ImpositionNUp.
+	Pages int `json:"pages,omitempty"`
+}
+
+// nUpPages are the supported page counts of n-up layouts.
+var nUpPages = map[int]bool{2: true, 4: true, 6: true, 8: true, 9: true, 16: true}
+
+// NUp places n pages on each sheet side, in reading order, scaled to fit.
+// n is 2, 4, 6, 8, 9, or 16.
+func NUp(n int) Imposition {
+	return Imposition{Layout: ImpositionNUp, Pages: n}
+}
+
+// Booklet places two pages side by side on each sheet side, ordered so
+// that the printed, folded, and stapled stack reads in order
+// (saddle-stitch). Blank pages pad the document to a multiple of four.
+var Booklet = Imposition{Layout: ImpositionBooklet}
+
+// problem describes why i is not a supported layout, or returns "".
+func (i Imposition) problem() string {
+	switch {
+	case i.Layout == ImpositionBooklet && i.Pages == 0:
+		return ""
+	case i.Layout == ImpositionNUp && nUpPages[i.Pages]:
+		return ""
+	case i.Layout == ImpositionNUp:
+		return fmt.Sprintf("unsupported n-up page count %d", i.Pages)
+	}
+	return "use NUp or Booklet"
+}
+
+// PdfImposition arranges the rendered PDF's pages on sheets for printing,
+// e.g. NUp(2) for handouts or Booklet for saddle-stitched brochures.
+func (r *RenderRequest) PdfImposition(i Imposition) *RenderRequest {
+	if msg := i.problem(); msg != "" {
+		r.fail("pdf.imposition", "%s", msg)
+		return r
+	}
+	r.pdf().Imposition = &i
+	return r
+}
+
+// Impose arranges the pages of an existing PDF on sheets for printing and
+// returns the imposed PDF.
+func (p *PdfClient) Impose(ctx context.Context, pdf []byte, i Imposition) ([]byte, error) {
+	if err := checkPDF("pdf", pdf); err != nil {
+		return nil, err
+	}
+	if msg := i.problem(); msg != "" {
+		return nil, &ValidationError{Field: "imposition", Message: msg}
+	}
+	return p.c.postJSON(ctx, "/pdf/impose", struct {
+		PDF        []byte     `json:"pdf"`
+		Imposition Imposition `json:"imposition"`
+	}{pdf, i})
+}
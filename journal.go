@@ -0,0 +1,171 @@
+package forge
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// WithJournal persists every render request to dir before it is sent and
+// removes it once the server has answered, so that requests lost to a
+// Forge outage (a connection error, 429, or 5xx) survive the process and
+// can be resubmitted with ReplayJournal. Secrets such as cookie values,
+// login form values, and signing keys are never written to disk: requests
+// carrying them are journaled redacted and cannot be replayed.
+func WithJournal(dir string) Option {
+	return func(c *Client) {
+		c.journal = &journal{dir: dir}
+	}
+}
+
+// journal stores pending render requests as one JSON file each.
+type journal struct {
+	dir string
+}
+
+type journalEntry struct {
+	CreatedAt time.Time      `json:"created_at"`
+	Accept    string         `json:"accept,omitempty"`
+	Via       string         `json:"via,omitempty"`
+	Redacted  bool           `json:"redacted,omitempty"`
+	Payload   *RenderPayload `json:"payload"`
+}
+
+// write records a pending request and returns its file name. Names sort
+// in creation order.
+func (j *journal) write(p *RenderPayload, accept, via string) (string, error) {
+	if err := os.MkdirAll(j.dir, 0o700); err != nil {
+		return "", err
+	}
+	p, secret := redactSecrets(p)
+	data, err := json.Marshal(journalEntry{CreatedAt: time.Now().UTC(), Accept: accept, Via: via, Redacted: secret, Payload: p})
+	if err != nil {
+		return "", err
+	}
+	var suffix [4]byte
+	if _, err := rand.Read(suffix[:]); err != nil {
+		return "", err
+	}
+	name := fmt.Sprintf("%020d-%s.json", time.Now().UnixNano(), hex.EncodeToString(suffix[:]))
+
+	// Write under a temporary name so a crash never leaves a partial entry.
+	tmp := filepath.Join(j.dir, name+".tmp")
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return "", err
+	}
+	if err := os.Rename(tmp, filepath.Join(j.dir, name)); err != nil {
+		os.Remove(tmp)
+		return "", err
+	}
+	return name, nil
+}
+
+func (j *journal) remove(name string) {
+	os.Remove(filepath.Join(j.dir, name))
+}
+
+// pending returns the names of the journaled requests, oldest first.
+func (j *journal) pending() ([]string, error) {
+	files, err := os.ReadDir(j.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, f := range files {
+		if !f.IsDir() && strings.HasSuffix(f.Name(), ".json") {
+			names = append(names, f.Name())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func (j *journal) read(name string) (*journalEntry, error) {
+	data, err := os.ReadFile(filepath.Join(j.dir, name))
+	if err != nil {
+		return nil, err
+	}
+	var e journalEntry
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, err
+	}
+	if e.Payload == nil {
+		return nil, errors.New("no payload")
+	}
+	return &e, nil
+}
+
+// isOutage reports whether a render attempt failed in a way that may
+// succeed later, so its journal entry must be kept.
+func isOutage(resp *http.Response, err error) bool {
+	if err != nil {
+		var ce *ConnectionError
+		return errors.As(err, &ce) || errors.Is(err, context.DeadlineExceeded)
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+}
+
+// ReplayJournal resubmits the requests left in the WithJournal directory,
+// oldest first, and returns how many the server accepted. Responses are
+// discarded, so replay suits fire-and-forget renders whose output is
+// delivered elsewhere.
+//
+// An entry is removed once the server answers it. Entries the server
+// rejects, and redacted entries, which are left in place, are reported in
+// the returned error. Replay stops at the first outage error, keeping that
+// entry and later ones for the next call. Run one replay at a time per
+// directory.
+func (c *Client) ReplayJournal(ctx context.Context) (int, error) {
+	if c.journal == nil {
+		return 0, errors.New("forge: no journal configured (see WithJournal)")
+	}
+	names, err := c.journal.pending()
+	if err != nil {
+		return 0, fmt.Errorf("forge: journal: %w", err)
+	}
+
+	replayed := 0
+	var errs []error
+	for _, name := range names {
+		e, err := c.journal.read(name)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("forge: journal entry %s: %w", name, err))
+			continue
+		}
+		if e.Redacted {
+			errs = append(errs, fmt.Errorf("forge: journal entry %s: secrets were redacted; cannot replay", name))
+			continue
+		}
+
+		resp, x, err := (&compiled{client: c, p: e.Payload, accept: e.Accept, via: e.Via}).post(ctx)
+		if isOutage(resp, err) {
+			if err == nil {
+				_, err = readResponse(resp, x)
+			}
+			errs = append(errs, fmt.Errorf("forge: journal entry %s: %w", name, err))
+			break
+		}
+		if err == nil {
+			_, err = readResponse(resp, x)
+		}
+		c.journal.remove(name)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("forge: journal entry %s: %w", name, err))
+			continue
+		}
+		replayed++
+	}
+	return replayed, errors.Join(errs...)
+}
@@ -0,0 +1,130 @@
+package forge
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func journalFiles(t *testing.T, dir string) []string {
+	t.Helper()
+	names, err := (&journal{dir: dir}).pending()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return names
+}
+
+func TestJournalReplay(t *testing.T) {
+	dir := t.TempDir()
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+
+	offline := NewClient(down.URL, WithJournal(dir))
+	if _, err := offline.RenderHTML("<p>one</p>").Send(context.Background()); err == nil {
+		t.Fatal("expected connection error")
+	}
+	if _, err := offline.RenderHTML("<p>two</p>").Format(FormatPNG).Send(context.Background()); err == nil {
+		t.Fatal("expected connection error")
+	}
+	if n := len(journalFiles(t, dir)); n != 2 {
+		t.Fatalf("journal has %d entries, want 2", n)
+	}
+
+	var got []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var p map[string]any
+		json.NewDecoder(r.Body).Decode(&p)
+		got = append(got, p["html"].(string))
+		w.Write([]byte("%PDF"))
+	}))
+	defer srv.Close()
+
+	n, err := NewClient(srv.URL, WithJournal(dir)).ReplayJournal(context.Background())
+	if err != nil || n != 2 {
+		t.Fatalf("ReplayJournal = %d, %v", n, err)
+	}
+	if strings.Join(got, ",") != "<p>one</p>,<p>two</p>" {
+		t.Errorf("replayed %v, want oldest first", got)
+	}
+	if names := journalFiles(t, dir); len(names) != 0 {
+		t.Errorf("journal not emptied: %v", names)
+	}
+}
+
+func TestJournalKeepsOnlyOutages(t *testing.T) {
+	dir := t.TempDir()
+	status := http.StatusOK
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+	c := NewClient(srv.URL, WithJournal(dir))
+
+	for _, tc := range []struct {
+		status int
+		kept   int
+	}{
+		{http.StatusOK, 0},
+		{http.StatusBadRequest, 0},
+		{http.StatusServiceUnavailable, 1},
+		{http.StatusTooManyRequests, 2},
+	} {
+		status = tc.status
+		c.RenderHTML("x").Send(context.Background())
+		if n := len(journalFiles(t, dir)); n != tc.kept {
+			t.Errorf("after %d: journal has %d entries, want %d", tc.status, n, tc.kept)
+		}
+	}
+
+	// Still down: the first entry is kept and replay stops.
+	status = http.StatusServiceUnavailable
+	if n, err := c.ReplayJournal(context.Background()); n != 0 || err == nil {
+		t.Errorf("ReplayJournal = %d, %v; want 0 and an error", n, err)
+	}
+	if n := len(journalFiles(t, dir)); n != 2 {
+		t.Errorf("journal has %d entries, want 2", n)
+	}
+
+	// A rejected entry is dropped and reported; replay continues.
+	status = http.StatusUnprocessableEntity
+	if n, err := c.ReplayJournal(context.Background()); n != 0 || err == nil {
+		t.Errorf("ReplayJournal = %d, %v; want 0 and an error", n, err)
+	}
+	if n := len(journalFiles(t, dir)); n != 0 {
+		t.Errorf("journal has %d entries, want 0", n)
+	}
+}
+
+func TestJournalRedactsSecrets(t *testing.T) {
+	dir := t.TempDir()
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+
+	c := NewClient(down.URL, WithJournal(dir))
+	c.RenderHTML("x").PdfUserPassword("hunter2").Send(context.Background())
+	names := journalFiles(t, dir)
+	if len(names) != 1 {
+		t.Fatalf("journal has %d entries, want 1", len(names))
+	}
+	data, err := os.ReadFile(filepath.Join(dir, names[0]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "hunter2") {
+		t.Errorf("secret written to journal: %s", data)
+	}
+
+	n, err := c.ReplayJournal(context.Background())
+	if n != 0 || err == nil || !strings.Contains(err.Error(), "redacted") {
+		t.Errorf("ReplayJournal = %d, %v; want a redaction error", n, err)
+	}
+	if len(journalFiles(t, dir)) != 1 {
+		t.Error("redacted entry removed")
+	}
+}
@@ -0,0 +1,58 @@
+package forge
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// RenderLink is a signed, one-time download URL for a render.
+type RenderLink struct {
+	// URL renders and downloads the document on first GET. Later requests
+	// and requests after ExpiresAt are rejected by the server.
+	URL       string
+	ExpiresAt time.Time
+}
+
+// CreateRenderLink registers req with the server and returns a signed URL
+// a browser can GET directly, so large documents stream from Forge to the
+// end user without passing through the caller's servers. ttl is rounded
+// up to whole seconds.
+func (c *Client) CreateRenderLink(ctx context.Context, req *RenderRequest, ttl time.Duration) (*RenderLink, error) {
+	if ttl <= 0 {
+		return nil, &ValidationError{Field: "ttl", Message: fmt.Sprintf("must be positive, got %v", ttl)}
+	}
+	if err := req.validate(); err != nil {
+		return nil, err
+	}
+	seconds := int((ttl + time.Second - 1) / time.Second)
+	data, err := c.postJSON(ctx, "/links", struct {
+		Payload *RenderPayload `json:"payload"`
+		TTL     int            `json:"ttl"`
+	}{req.Payload(), seconds})
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		URL       string    `json:"url"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("forge: decode render link: %w", err)
+	}
+	link, err := url.Parse(resp.URL)
+	if err != nil || resp.URL == "" {
+		return nil, fmt.Errorf("forge: invalid render link %q", resp.URL)
+	}
+	if !link.IsAbs() {
+		base, err := url.Parse(c.baseURL + "/")
+		if err != nil {
+			return nil, fmt.Errorf("forge: invalid base URL: %w", err)
+		}
+		link = base.ResolveReference(link)
+	}
+	return &RenderLink{URL: link.String(), ExpiresAt: resp.ExpiresAt}, nil
+}
@@ -0,0 +1,44 @@
+package forge
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCreateRenderLink(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/links" {
+			t.Errorf("request = %s %s", r.Method, r.URL.Path)
+		}
+		var body struct {
+			Payload map[string]any `json:"payload"`
+			TTL     int            `json:"ttl"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body.TTL != 91 || body.Payload["html"] != "<h1>Report</h1>" {
+			t.Errorf("body = %+v", body)
+		}
+		w.Write([]byte(`{"url":"/links/abc?sig=xyz","expires_at":"2026-01-02T03:04:05Z"}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+	link, err := c.CreateRenderLink(context.Background(), c.RenderHTML("<h1>Report</h1>"), 90*time.Second+time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if link.URL != srv.URL+"/links/abc?sig=xyz" {
+		t.Errorf("URL = %q", link.URL)
+	}
+	if !link.ExpiresAt.Equal(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)) {
+		t.Errorf("ExpiresAt = %v", link.ExpiresAt)
+	}
+
+	if _, err := c.CreateRenderLink(context.Background(), c.RenderHTML("x"), 0); err == nil {
+		t.Error("zero ttl accepted")
+	}
+}
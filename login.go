@@ -0,0 +1,103 @@
+package forge
+
+import "net/url"
+
+// LoginAction is a kind of LoginStep.
+type LoginAction string
+
+const (
+	LoginActionFill    LoginAction = "fill"
+	LoginActionClick   LoginAction = "click"
+	LoginActionWaitFor LoginAction = "wait_for"
+)
+
+// LoginStep is one browser action of a LoginScript.
+type LoginStep struct {
+	Action   LoginAction `json:"action"`
+	Selector string      `json:"selector"`
+	// Value is the text typed by LoginActionFill.
+	Value string `json:"value,omitempty"`
+}
+
+// LoginFill types value into the input matching selector.
+func LoginFill(selector, value string) LoginStep {
+	return LoginStep{Action: LoginActionFill, Selector: selector, Value: value}
+}
+
+// LoginClick clicks the element matching selector.
+func LoginClick(selector string) LoginStep {
+	return LoginStep{Action: LoginActionClick, Selector: selector}
+}
+
+// LoginWaitFor waits until an element matches selector.
+func LoginWaitFor(selector string) LoginStep {
+	return LoginStep{Action: LoginActionWaitFor, Selector: selector}
+}
+
+// LoginScript logs the rendering browser into a site before it navigates
+// to the RenderURL target.
+type LoginScript struct {
+	// URL is the login page.
+	URL   string      `json:"url"`
+	Steps []LoginStep `json:"steps"`
+	// SuccessSelector matches an element present only once logged in; the
+	// render fails if it does not appear.
+	SuccessSelector string `json:"success_selector"`
+	// Session names a server-side browser session to keep the resulting
+	// cookies in. Later renders with the same Session skip the login while
+	// its cookies remain valid. Empty logs in for every render.
+	Session string `json:"session,omitempty"`
+	// SessionTTL bounds how long the session's cookies are reused, in
+	// seconds (server default when zero).
+	SessionTTL int `json:"session_ttl,omitempty"`
+}
+
+// FetchLogin runs a login script in the rendering browser before it
+// navigates to the RenderURL target, so portal pages can be rendered
+// without minting session cookies elsewhere:
+//
+//	req.FetchLogin(forge.LoginScript{
+//		URL: "https://portal.example.com/login",
+//		Steps: []forge.LoginStep{
+//			forge.LoginFill("#user", user),
+//			forge.LoginFill("#password", password),
+//			forge.LoginClick("button[type=submit]"),
+//		},
+//		SuccessSelector: "#account-menu",
+//		Session:         "portal",
+//	})
+//
+// Fill values are redacted from debug bundles and journals.
+func (r *RenderRequest) FetchLogin(script LoginScript) *RenderRequest {
+	if u, err := url.Parse(script.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		r.fail("login.url", "must be an absolute http(s) URL, got %q", script.URL)
+		return r
+	}
+	if len(script.Steps) == 0 {
+		r.fail("login.steps", "at least one step is required")
+		return r
+	}
+	for i, s := range script.Steps {
+		switch s.Action {
+		case LoginActionFill, LoginActionClick, LoginActionWaitFor:
+		default:
+			r.fail("login.steps", "step %d: unknown action %q", i+1, s.Action)
+			return r
+		}
+		if s.Selector == "" {
+			r.fail("login.steps", "step %d: selector is required", i+1)
+			return r
+		}
+	}
+	if script.SuccessSelector == "" {
+		r.fail("login.success_selector", "is required")
+		return r
+	}
+	if script.SessionTTL < 0 {
+		r.fail("login.session_ttl", "must not be negative")
+		return r
+	}
+	script.Steps = append([]LoginStep(nil), script.Steps...)
+	r.p.Login = &script
+	return r
+}
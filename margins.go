@@ -0,0 +1,56 @@
+package forge
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Margins are page margins in a single unit. An empty Unit means UnitMM.
+type Margins struct {
+	Top    float64
+	Right  float64
+	Bottom float64
+	Left   float64
+	Unit   Unit
+}
+
+// mmPerUnit converts each supported unit to millimeters.
+var mmPerUnit = map[Unit]float64{
+	"":     1,
+	UnitMM: 1,
+	UnitIn: 25.4,
+	UnitPt: 25.4 / 72,
+	UnitPx: 25.4 / 96,
+}
+
+// MarginsWith sets page margins from a Margins value. Negative values and
+// unknown units are reported by Send.
+func (r *RenderRequest) MarginsWith(m Margins) *RenderRequest {
+	scale, ok := mmPerUnit[m.Unit]
+	if !ok {
+		r.fail("margins", "unknown unit %q", m.Unit)
+		return r
+	}
+	sides := []float64{m.Top, m.Right, m.Bottom, m.Left}
+	parts := make([]string, len(sides))
+	for i, v := range sides {
+		if v < 0 || math.IsNaN(v) || math.IsInf(v, 0) {
+			r.fail("margins", "invalid margin %v", v)
+			return r
+		}
+		mm := math.Round(v*scale*1000) / 1000
+		parts[i] = strconv.FormatFloat(mm, 'f', -1, 64)
+	}
+	return r.Margins(strings.Join(parts, ","))
+}
+
+// MarginsAll sets the same margin on all four sides.
+func (r *RenderRequest) MarginsAll(v float64, unit Unit) *RenderRequest {
+	return r.MarginsWith(Margins{Top: v, Right: v, Bottom: v, Left: v, Unit: unit})
+}
+
+// MarginsTRBL sets the top, right, bottom, and left margins.
+func (r *RenderRequest) MarginsTRBL(top, right, bottom, left float64, unit Unit) *RenderRequest {
+	return r.MarginsWith(Margins{Top: top, Right: right, Bottom: bottom, Left: left, Unit: unit})
+}
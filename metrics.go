@@ -0,0 +1,83 @@
+package forge
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// durationBuckets are the upper bounds, in seconds, of the request duration histogram.
+var durationBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120}
+
+// MetricsSnapshot is a point-in-time copy of a client's request statistics.
+type MetricsSnapshot struct {
+	// Requests is the number of HTTP requests sent to the server.
+	Requests uint64
+	// ConnectionErrors counts requests that failed before a response arrived.
+	ConnectionErrors uint64
+	// ServerErrors counts responses with a 4xx/5xx status.
+	ServerErrors uint64
+	// InFlight is the number of requests currently awaiting a response.
+	InFlight int64
+	// BytesSent is the total size of request bodies sent.
+	BytesSent uint64
+	// DurationBuckets are the histogram upper bounds in seconds.
+	DurationBuckets []float64
+	// DurationCounts holds the cumulative count for each DurationBuckets bound.
+	DurationCounts []uint64
+	// DurationSum is the total time spent waiting for response headers, in seconds.
+	DurationSum float64
+	// DurationCount is the number of observed request durations.
+	DurationCount uint64
+}
+
+// metrics accumulates request statistics for a Client.
+type metrics struct {
+	mu   sync.Mutex
+	snap MetricsSnapshot
+}
+
+func newMetrics() *metrics {
+	return &metrics{snap: MetricsSnapshot{DurationCounts: make([]uint64, len(durationBuckets))}}
+}
+
+func (m *metrics) begin(bodySize int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.snap.Requests++
+	m.snap.InFlight++
+	if bodySize > 0 {
+		m.snap.BytesSent += uint64(bodySize)
+	}
+}
+
+func (m *metrics) end(d time.Duration, resp *http.Response, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.snap.InFlight--
+	if err != nil {
+		m.snap.ConnectionErrors++
+		return
+	}
+	if resp.StatusCode >= 400 {
+		m.snap.ServerErrors++
+	}
+	secs := d.Seconds()
+	for i, le := range durationBuckets {
+		if secs <= le {
+			m.snap.DurationCounts[i]++
+		}
+	}
+	m.snap.DurationSum += secs
+	m.snap.DurationCount++
+}
+
+// Metrics returns a snapshot of the client's request statistics.
+func (c *Client) Metrics() MetricsSnapshot {
+	c.metrics.mu.Lock()
+	defer c.metrics.mu.Unlock()
+	s := c.metrics.snap
+	s.DurationBuckets = append([]float64(nil), durationBuckets...)
+	s.DurationCounts = append([]uint64(nil), s.DurationCounts...)
+	return s
+}
@@ -0,0 +1,71 @@
+// Package metricsbridge exposes a forge.Client's request statistics in the
+// Prometheus text exposition format.
+//
+// It depends only on the standard library, so services without a metrics
+// stack can mount Handler directly. Services that run a Prometheus
+// registry can register the prometheus.Collector from the separate
+// metricsbridge/promcollector module instead, which reports the same
+// metrics without adding client_golang to every SDK user's build.
+package metricsbridge
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	forge "github.com/centrixsystems/forge-sdk-go"
+)
+
+// Handler returns an http.Handler serving the client's metrics.
+func Handler(c *forge.Client) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		WriteText(w, c.Metrics())
+	})
+}
+
+// WriteText writes s in the Prometheus text exposition format.
+func WriteText(w io.Writer, s forge.MetricsSnapshot) error {
+	ew := &errWriter{w: w}
+
+	counter(ew, "forge_client_requests_total", "HTTP requests sent to the Forge server.", s.Requests)
+	counter(ew, "forge_client_connection_errors_total", "Requests that failed before a response arrived.", s.ConnectionErrors)
+	counter(ew, "forge_client_server_errors_total", "Responses with a 4xx/5xx status.", s.ServerErrors)
+	counter(ew, "forge_client_request_bytes_total", "Request body bytes sent.", s.BytesSent)
+
+	ew.printf("# HELP forge_client_in_flight_requests Requests awaiting a response.\n")
+	ew.printf("# TYPE forge_client_in_flight_requests gauge\n")
+	ew.printf("forge_client_in_flight_requests %d\n", s.InFlight)
+
+	const name = "forge_client_request_duration_seconds"
+	ew.printf("# HELP %s Time until response headers were received.\n", name)
+	ew.printf("# TYPE %s histogram\n", name)
+	for i, le := range s.DurationBuckets {
+		ew.printf("%s_bucket{le=%q} %d\n", name, strconv.FormatFloat(le, 'g', -1, 64), s.DurationCounts[i])
+	}
+	ew.printf("%s_bucket{le=\"+Inf\"} %d\n", name, s.DurationCount)
+	ew.printf("%s_sum %s\n", name, strconv.FormatFloat(s.DurationSum, 'g', -1, 64))
+	ew.printf("%s_count %d\n", name, s.DurationCount)
+
+	return ew.err
+}
+
+func counter(ew *errWriter, name, help string, v uint64) {
+	ew.printf("# HELP %s %s\n", name, help)
+	ew.printf("# TYPE %s counter\n", name)
+	ew.printf("%s %d\n", name, v)
+}
+
+// errWriter records the first write error and skips later writes.
+type errWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (e *errWriter) printf(format string, args ...any) {
+	if e.err != nil {
+		return
+	}
+	_, e.err = fmt.Fprintf(e.w, format, args...)
+}
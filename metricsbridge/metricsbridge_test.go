@@ -0,0 +1,46 @@
+package metricsbridge
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	forge "github.com/centrixsystems/forge-sdk-go"
+)
+
+func TestHandler(t *testing.T) {
+	forgeSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/render" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer forgeSrv.Close()
+
+	c := forge.NewClient(forgeSrv.URL)
+	c.Health(context.Background())
+	c.RenderHTML("<p>x</p>").Send(context.Background())
+
+	rec := httptest.NewRecorder()
+	Handler(c).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body, _ := io.ReadAll(rec.Body)
+	out := string(body)
+
+	for _, want := range []string{
+		"forge_client_requests_total 2\n",
+		"forge_client_server_errors_total 1\n",
+		"forge_client_connection_errors_total 0\n",
+		"forge_client_in_flight_requests 0\n",
+		"forge_client_request_duration_seconds_bucket{le=\"+Inf\"} 2\n",
+		"forge_client_request_duration_seconds_count 2\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q\n%s", want, out)
+		}
+	}
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain") {
+		t.Errorf("Content-Type = %q", ct)
+	}
+}
@@ -0,0 +1,71 @@
+// Package promcollector exposes a forge.Client's request statistics as a
+// prometheus.Collector, for services that already run a Prometheus
+// registry.
+//
+// It is a separate module, so that only services that use it depend on
+// client_golang. Services without a metrics stack can use the
+// dependency-free metricsbridge.Handler instead; both report the same
+// metric names.
+package promcollector
+
+import (
+	forge "github.com/centrixsystems/forge-sdk-go"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Collector is a prometheus.Collector reporting a client's metrics. Create
+// one with New.
+type Collector struct {
+	c *forge.Client
+
+	requests, connectionErrors, serverErrors, bytesSent *prometheus.Desc
+	inFlight, duration                                  *prometheus.Desc
+}
+
+// New returns a Collector for c. labels are added to every metric, which
+// distinguishes several clients registered with the same registry; it
+// may be nil.
+func New(c *forge.Client, labels prometheus.Labels) *Collector {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(name, help, nil, labels)
+	}
+	return &Collector{
+		c:                c,
+		requests:         desc("forge_client_requests_total", "HTTP requests sent to the Forge server."),
+		connectionErrors: desc("forge_client_connection_errors_total", "Requests that failed before a response arrived."),
+		serverErrors:     desc("forge_client_server_errors_total", "Responses with a 4xx/5xx status."),
+		bytesSent:        desc("forge_client_request_bytes_total", "Request body bytes sent."),
+		inFlight:         desc("forge_client_in_flight_requests", "Requests awaiting a response."),
+		duration:         desc("forge_client_request_duration_seconds", "Time until response headers were received."),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (col *Collector) Describe(ch chan<- *prometheus.Desc) {
+	for _, d := range []*prometheus.Desc{col.requests, col.connectionErrors, col.serverErrors, col.bytesSent, col.inFlight, col.duration} {
+		ch <- d
+	}
+}
+
+// Collect implements prometheus.Collector.
+func (col *Collector) Collect(ch chan<- prometheus.Metric) {
+	s := col.c.Metrics()
+	for _, m := range []struct {
+		desc *prometheus.Desc
+		v    uint64
+	}{
+		{col.requests, s.Requests},
+		{col.connectionErrors, s.ConnectionErrors},
+		{col.serverErrors, s.ServerErrors},
+		{col.bytesSent, s.BytesSent},
+	} {
+		ch <- prometheus.MustNewConstMetric(m.desc, prometheus.CounterValue, float64(m.v))
+	}
+	ch <- prometheus.MustNewConstMetric(col.inFlight, prometheus.GaugeValue, float64(s.InFlight))
+
+	buckets := make(map[float64]uint64, len(s.DurationBuckets))
+	for i, le := range s.DurationBuckets {
+		buckets[le] = s.DurationCounts[i]
+	}
+	ch <- prometheus.MustNewConstHistogram(col.duration, s.DurationCount, s.DurationSum, buckets)
+}
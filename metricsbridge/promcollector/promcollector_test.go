@@ -0,0 +1,50 @@
+package promcollector
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	forge "github.com/centrixsystems/forge-sdk-go"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestCollector(t *testing.T) {
+	forgeSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/render" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer forgeSrv.Close()
+
+	c := forge.NewClient(forgeSrv.URL)
+	c.Health(context.Background())
+	c.RenderHTML("<p>x</p>").Send(context.Background())
+
+	reg := prometheus.NewPedanticRegistry()
+	if err := reg.Register(New(c, prometheus.Labels{"client": "primary"})); err != nil {
+		t.Fatal(err)
+	}
+	want := `
+# HELP forge_client_requests_total HTTP requests sent to the Forge server.
+# TYPE forge_client_requests_total counter
+forge_client_requests_total{client="primary"} 2
+# HELP forge_client_server_errors_total Responses with a 4xx/5xx status.
+# TYPE forge_client_server_errors_total counter
+forge_client_server_errors_total{client="primary"} 1
+# HELP forge_client_in_flight_requests Requests awaiting a response.
+# TYPE forge_client_in_flight_requests gauge
+forge_client_in_flight_requests{client="primary"} 0
+`
+	err := testutil.GatherAndCompare(reg, strings.NewReader(want),
+		"forge_client_requests_total", "forge_client_server_errors_total", "forge_client_in_flight_requests")
+	if err != nil {
+		t.Error(err)
+	}
+	if n, err := testutil.GatherAndCount(reg, "forge_client_request_duration_seconds"); err != nil || n != 1 {
+		t.Errorf("duration histogram: %d, %v", n, err)
+	}
+}
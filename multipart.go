@@ -0,0 +1,43 @@
+package forge
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/textproto"
+	"strings"
+)
+
+// responsePart is one part of a multipart render response.
+type responsePart struct {
+	header textproto.MIMEHeader
+	data   []byte
+}
+
+// splitMultipart splits a multipart response body. It returns nil parts
+// and no error if contentType is not multipart.
+func splitMultipart(contentType string, body []byte) ([]responsePart, error) {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil || !strings.HasPrefix(mediaType, "multipart/") {
+		return nil, nil
+	}
+	mr := multipart.NewReader(bytes.NewReader(body), params["boundary"])
+	var parts []responsePart
+	for {
+		p, err := mr.NextPart()
+		if errors.Is(err, io.EOF) {
+			return parts, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("forge: multipart response: %w", err)
+		}
+		data, err := io.ReadAll(p)
+		if err != nil {
+			return nil, fmt.Errorf("forge: multipart response: %w", err)
+		}
+		parts = append(parts, responsePart{header: p.Header, data: data})
+	}
+}
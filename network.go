@@ -0,0 +1,132 @@
+package forge
+
+import (
+	"net/url"
+	"strings"
+)
+
+// NetworkMode is how a NetworkPolicy restricts outbound requests.
+type NetworkMode string
+
+const (
+	NetworkOffline   NetworkMode = "offline"
+	NetworkAllowlist NetworkMode = "allowlist"
+)
+
+// NetworkOptions is the wire form of a NetworkPolicy.
+type NetworkOptions struct {
+	Mode  NetworkMode `json:"mode"`
+	Hosts []string    `json:"hosts,omitempty"`
+}
+
+// NetworkPolicy restricts the outbound requests the rendering browser may
+// make. Use Offline or AllowlistOnly.
+type NetworkPolicy struct {
+	mode  NetworkMode
+	hosts []string
+}
+
+// Offline blocks all outbound requests. Only content shipped with the
+// request, such as the HTML, assets, and fonts, is available.
+var Offline = NetworkPolicy{mode: NetworkOffline}
+
+// AllowlistOnly blocks outbound requests except to the given hosts. A host
+// of the form "*.example.com" allows every subdomain of example.com, but
+// not example.com itself. Hosts match on any port.
+func AllowlistOnly(hosts ...string) NetworkPolicy {
+	return NetworkPolicy{mode: NetworkAllowlist, hosts: hosts}
+}
+
+// NetworkPolicy restricts the outbound requests made while rendering, e.g.
+// to keep user-supplied HTML from reaching internal services or leaking
+// data. The server enforces the policy for the document, its
+// sub-resources, and script requests.
+func (r *RenderRequest) NetworkPolicy(p NetworkPolicy) *RenderRequest {
+	switch p.mode {
+	case NetworkOffline:
+		r.p.Network = &NetworkOptions{Mode: NetworkOffline}
+	case NetworkAllowlist:
+		if len(p.hosts) == 0 {
+			r.fail("network.hosts", "allowlist must not be empty; use Offline to block all requests")
+			return r
+		}
+		hosts := make([]string, len(p.hosts))
+		for i, h := range p.hosts {
+			if !validHostPattern(h) {
+				r.fail("network.hosts", "invalid host %q", h)
+				return r
+			}
+			hosts[i] = strings.ToLower(h)
+		}
+		r.p.Network = &NetworkOptions{Mode: NetworkAllowlist, Hosts: hosts}
+	default:
+		r.fail("network", "unknown policy; use Offline or AllowlistOnly")
+	}
+	return r
+}
+
+// validHostPattern reports whether h is a host name or IPv4 address,
+// without a port, optionally prefixed with "*." to match subdomains.
+func validHostPattern(h string) bool {
+	h = strings.TrimPrefix(h, "*.")
+	if h == "" || len(h) > 253 {
+		return false
+	}
+	for _, c := range h {
+		ok := c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '.'
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// allowsHost reports whether the allowlist patterns admit host.
+func allowsHost(patterns []string, host string) bool {
+	host = strings.ToLower(host)
+	for _, p := range patterns {
+		if suffix, ok := strings.CutPrefix(p, "*"); ok {
+			if strings.HasSuffix(host, suffix) {
+				return true
+			}
+		} else if host == p {
+			return true
+		}
+	}
+	return false
+}
+
+// validateNetwork rejects fetches the network policy would block anyway:
+// the RenderURL target and the login page.
+func (r *RenderRequest) validateNetwork() error {
+	n := r.p.Network
+	if n == nil {
+		return nil
+	}
+	for _, target := range []struct {
+		field string
+		url   *string
+	}{
+		{"url", r.p.URL},
+		{"login.url", loginURL(r.p.Login)},
+	} {
+		if target.url == nil {
+			continue
+		}
+		if n.Mode == NetworkOffline {
+			return &ValidationError{Field: target.field, Message: "cannot be fetched with the Offline network policy"}
+		}
+		u, err := url.Parse(*target.url)
+		if err == nil && !allowsHost(n.Hosts, u.Hostname()) {
+			return &ValidationError{Field: target.field, Message: "host " + u.Hostname() + " is not in the network allowlist"}
+		}
+	}
+	return nil
+}
+
+func loginURL(l *LoginScript) *string {
+	if l == nil {
+		return nil
+	}
+	return &l.URL
+}
@@ -0,0 +1,57 @@
+package forge
+
+import (
+	"maps"
+	"sort"
+)
+
+// PageOrientationOverrides sets the orientation of individual pages,
+// keyed by page list (e.g. "1,3-5" or "12-" for page 12 onwards), so wide
+// tables can be landscape inside an otherwise portrait document. Pages not
+// listed use Orientation. Page lists must not overlap.
+func (r *RenderRequest) PageOrientationOverrides(overrides map[string]Orientation) *RenderRequest {
+	keys := make([]string, 0, len(overrides))
+	for k := range overrides {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	type owned struct {
+		PageRange
+		key string
+	}
+	var seen []owned
+	for _, k := range keys {
+		switch o := overrides[k]; o {
+		case Portrait, Landscape:
+		default:
+			r.fail("orientation_overrides", "unknown orientation %q for pages %q", o, k)
+			return r
+		}
+		ranges, err := parsePageRanges(k)
+		if err != nil {
+			r.fail("orientation_overrides", "%v", err)
+			return r
+		}
+		for _, pr := range ranges {
+			for _, s := range seen {
+				if pr.overlaps(s.PageRange) {
+					r.fail("orientation_overrides", "pages %q and %q overlap", s.key, k)
+					return r
+				}
+			}
+			seen = append(seen, owned{pr, k})
+		}
+	}
+	r.p.OrientationOverrides = maps.Clone(overrides)
+	return r
+}
+
+// validateOrientationOverrides rejects overrides on continuous output,
+// which has no pages.
+func (r *RenderRequest) validateOrientationOverrides() error {
+	if r.p.OrientationOverrides != nil && r.p.Flow != nil && *r.p.Flow == FlowContinuous {
+		return &ValidationError{Field: "orientation_overrides", Message: "continuous flow has no pages"}
+	}
+	return nil
+}
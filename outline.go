@@ -0,0 +1,77 @@
+package forge
+
+import (
+	"slices"
+	"strings"
+)
+
+// BookmarkOutline customizes how PDF bookmarks are generated.
+type BookmarkOutline struct {
+	// Selectors maps outline levels, 1-6, to the CSS selectors of the
+	// elements that become bookmarks at that level.
+	Selectors map[int]string `json:"selectors,omitempty"`
+	// Collapsed lists the levels whose bookmarks start collapsed.
+	Collapsed []int `json:"collapsed,omitempty"`
+}
+
+func (r *RenderRequest) outline() *BookmarkOutline {
+	pdf := r.pdf()
+	if pdf.BookmarkOutline == nil {
+		pdf.BookmarkOutline = &BookmarkOutline{}
+	}
+	return pdf.BookmarkOutline
+}
+
+// PdfBookmarkSelectors generates bookmarks from arbitrary elements instead
+// of h1-h6, mapping outline levels to CSS selectors, and enables bookmarks:
+//
+//	req.PdfBookmarkSelectors(map[int]string{1: ".chapter-title", 2: "section > h2"})
+func (r *RenderRequest) PdfBookmarkSelectors(selectors map[int]string) *RenderRequest {
+	for level, sel := range selectors {
+		if level < 1 || level > 6 {
+			r.fail("pdf.bookmark_outline.selectors", "level must be between 1 and 6, got %d", level)
+			return r
+		}
+		if strings.TrimSpace(sel) == "" {
+			r.fail("pdf.bookmark_outline.selectors", "empty selector for level %d", level)
+			return r
+		}
+	}
+	o := r.outline()
+	if o.Selectors == nil {
+		o.Selectors = make(map[int]string, len(selectors))
+	}
+	for level, sel := range selectors {
+		o.Selectors[level] = sel
+	}
+	return r.PdfBookmarks(true)
+}
+
+// PdfBookmarkCollapsed makes the bookmarks at the given outline levels,
+// 1-6, start collapsed, hiding their children until expanded. By default
+// every level starts expanded.
+func (r *RenderRequest) PdfBookmarkCollapsed(levels ...int) *RenderRequest {
+	o := r.outline()
+	for _, level := range levels {
+		if level < 1 || level > 6 {
+			r.fail("pdf.bookmark_outline.collapsed", "level must be between 1 and 6, got %d", level)
+			return r
+		}
+		if !slices.Contains(o.Collapsed, level) {
+			o.Collapsed = append(o.Collapsed, level)
+		}
+	}
+	return r
+}
+
+// validateOutline rejects bookmark customizations when bookmarks are off.
+func (r *RenderRequest) validateOutline() error {
+	pdf := r.p.Pdf
+	if pdf == nil || pdf.BookmarkOutline == nil {
+		return nil
+	}
+	if pdf.Bookmarks == nil || !*pdf.Bookmarks {
+		return &ValidationError{Field: "pdf.bookmark_outline", Message: "requires PdfBookmarks(true)"}
+	}
+	return nil
+}
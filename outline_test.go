@@ -0,0 +1,42 @@
+package forge
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPdfBookmarkSelectors(t *testing.T) {
+	c := NewClient("http://localhost")
+	r := c.RenderHTML("x").
+		PdfBookmarkSelectors(map[int]string{1: ".chapter-title"}).
+		PdfBookmarkSelectors(map[int]string{2: "section > h2"}).
+		PdfBookmarkCollapsed(2, 2)
+	pdf := payloadMap(t, r)["pdf"].(map[string]any)
+	if pdf["bookmarks"] != true {
+		t.Errorf("bookmarks = %v", pdf["bookmarks"])
+	}
+	want := map[string]any{
+		"selectors": map[string]any{"1": ".chapter-title", "2": "section > h2"},
+		"collapsed": []any{2.0},
+	}
+	if got := pdf["bookmark_outline"]; !reflect.DeepEqual(got, want) {
+		t.Errorf("bookmark_outline = %v, want %v", got, want)
+	}
+
+	tests := []struct {
+		r     *RenderRequest
+		field string
+	}{
+		{c.RenderHTML("x").PdfBookmarkSelectors(map[int]string{0: "h1"}), "pdf.bookmark_outline.selectors"},
+		{c.RenderHTML("x").PdfBookmarkSelectors(map[int]string{1: ""}), "pdf.bookmark_outline.selectors"},
+		{c.RenderHTML("x").PdfBookmarks(true).PdfBookmarkCollapsed(7), "pdf.bookmark_outline.collapsed"},
+		{c.RenderHTML("x").PdfBookmarkCollapsed(1), "pdf.bookmark_outline"},
+		{c.RenderHTML("x").PdfBookmarkSelectors(map[int]string{1: "h1"}).PdfBookmarks(false), "pdf.bookmark_outline"},
+	}
+	for _, tt := range tests {
+		err := tt.r.validate()
+		if ve, ok := err.(*ValidationError); !ok || ve.Field != tt.field {
+			t.Errorf("got %v, want %s error", err, tt.field)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package forge
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// PageRange is an inclusive range of 1-based page numbers. A zero To
+// means the range runs to the end of the document.
+type PageRange struct {
+	From int
+	To   int
+}
+
+// OnePage returns a range covering only page n.
+func OnePage(n int) PageRange {
+	return PageRange{From: n, To: n}
+}
+
+// PageSpan returns a range covering pages from through to.
+func PageSpan(from, to int) PageRange {
+	return PageRange{From: from, To: to}
+}
+
+// PagesFrom returns a range covering page from and every page after it.
+func PagesFrom(from int) PageRange {
+	return PageRange{From: from}
+}
+
+// String formats the range in page-spec syntax, e.g. "3", "3-5", or "3-".
+func (pr PageRange) String() string {
+	switch pr.To {
+	case pr.From:
+		return strconv.Itoa(pr.From)
+	case 0:
+		return strconv.Itoa(pr.From) + "-"
+	}
+	return strconv.Itoa(pr.From) + "-" + strconv.Itoa(pr.To)
+}
+
+// valid reports whether the range is well-formed.
+func (pr PageRange) valid() bool {
+	return pr.From >= 1 && (pr.To == 0 || pr.To >= pr.From)
+}
+
+func (pr PageRange) overlaps(o PageRange) bool {
+	return (pr.To == 0 || o.From <= pr.To) && (o.To == 0 || pr.From <= o.To)
+}
+
+// parsePageRanges parses a page spec such as "1,3-5,9-".
+func parsePageRanges(s string) ([]PageRange, error) {
+	var ranges []PageRange
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		lo, hi, isRange := strings.Cut(part, "-")
+		from, err := strconv.Atoi(lo)
+		if err != nil || from < 1 {
+			return nil, fmt.Errorf("invalid page %q", part)
+		}
+		pr := PageRange{From: from, To: from}
+		if isRange {
+			pr.To = 0
+			if hi != "" {
+				if pr.To, err = strconv.Atoi(hi); err != nil || !pr.valid() || pr.To == 0 {
+					return nil, fmt.Errorf("invalid page range %q", part)
+				}
+			}
+		}
+		ranges = append(ranges, pr)
+	}
+	return ranges, nil
+}
+
+// FormatPageRanges joins ranges into a page spec such as "1,3-5", as
+// accepted by Pages, PdfWatermarkPages, and BarcodeConfig.Pages.
+func FormatPageRanges(ranges ...PageRange) string {
+	parts := make([]string, len(ranges))
+	for i, pr := range ranges {
+		parts[i] = pr.String()
+	}
+	return strings.Join(parts, ",")
+}
+
+// Pages limits the output to the given pages of the paginated document,
+// e.g. "1,3-5" or "9-" for page 9 onwards. Applies to both PDF and image
+// output.
+func (r *RenderRequest) Pages(spec string) *RenderRequest {
+	if _, err := parsePageRanges(spec); err != nil {
+		r.fail("pages", "%v", err)
+		return r
+	}
+	r.p.Pages = &spec
+	return r
+}
+
+// PageRanges limits the output to the given page ranges.
+func (r *RenderRequest) PageRanges(ranges ...PageRange) *RenderRequest {
+	for _, pr := range ranges {
+		if !pr.valid() {
+			r.fail("pages", "invalid page range %d-%d", pr.From, pr.To)
+			return r
+		}
+	}
+	return r.Pages(FormatPageRanges(ranges...))
+}
+
+// Page is one page image of a SendPages render or PdfClient.ToImages
+// conversion.
+type Page struct {
+	// Number is the 1-based page number.
+	Number int
+	// ContentType is the MIME type of Data, e.g. "image/png".
+	ContentType string
+	Data        []byte
+}
+
+// SendPages renders a paginated document to an image format and returns
+// every page as a separate image, instead of only the first page. The
+// flow defaults to FlowPaginate; FlowContinuous is rejected.
+func (r *RenderRequest) SendPages(ctx context.Context) ([]Page, error) {
+	if r.p.Format == "" || r.p.Format == FormatPDF || r.p.Format == FormatAuto {
+		return nil, &ValidationError{Field: "format", Message: "SendPages requires an image format"}
+	}
+	if r.p.Flow != nil && *r.p.Flow == FlowContinuous {
+		return nil, &ValidationError{Field: "flow", Message: "continuous flow renders a single page"}
+	}
+
+	pr := *r
+	split := true
+	pr.p.SplitPages = &split
+	if pr.p.Flow == nil {
+		flow := FlowPaginate
+		pr.p.Flow = &flow
+	}
+	resp, x, err := pr.send(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return readPages(resp, x)
+}
+
+// readPages reads a response holding one image per page, as a multipart
+// body or, for a single page, as a plain body.
+func readPages(resp *http.Response, x *exchange) ([]Page, error) {
+	data, err := readResponse(resp, x)
+	if err != nil {
+		return nil, err
+	}
+
+	parts, err := splitMultipart(resp.Header.Get("Content-Type"), data)
+	if err != nil {
+		return nil, err
+	}
+	if parts == nil {
+		return []Page{{Number: 1, ContentType: resp.Header.Get("Content-Type"), Data: data}}, nil
+	}
+	pages := make([]Page, len(parts))
+	for i, p := range parts {
+		n := i + 1
+		if h := p.header.Get("X-Forge-Page"); h != "" {
+			if n, err = strconv.Atoi(h); err != nil {
+				return nil, fmt.Errorf("forge: invalid X-Forge-Page %q", h)
+			}
+		}
+		pages[i] = Page{Number: n, ContentType: p.header.Get("Content-Type"), Data: p.data}
+	}
+	return pages, nil
+}
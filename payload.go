@@ -0,0 +1,182 @@
+package forge
+
+// RenderPayload is the JSON body of a render request.
+//
+// Nil and empty fields are omitted so the server applies its defaults.
+type RenderPayload struct {
+	HTML                 *string                `json:"html,omitempty"`
+	URL                  *string                `json:"url,omitempty"`
+	Archive              *ArchiveSource         `json:"archive,omitempty"`
+	Assets               []Asset                `json:"assets,omitempty"`
+	Cookies              []PageCookie           `json:"cookies,omitempty"`
+	PageHeaders          map[string]string      `json:"page_headers,omitempty"`
+	PageUserAgent        *string                `json:"page_user_agent,omitempty"`
+	PageProxy            *string                `json:"page_proxy,omitempty"`
+	Network              *NetworkOptions        `json:"network,omitempty"`
+	Block                *BlockOptions          `json:"block,omitempty"`
+	Login                *LoginScript           `json:"login,omitempty"`
+	Fonts                []FontFace             `json:"fonts,omitempty"`
+	Format               OutputFormat           `json:"format,omitempty"`
+	Width                *int                   `json:"width,omitempty"`
+	Height               *int                   `json:"height,omitempty"`
+	Paper                *Paper                 `json:"paper,omitempty"`
+	PaperSize            *PaperDimensions       `json:"paper_size,omitempty"`
+	PreferCSSPageSize    *bool                  `json:"prefer_css_page_size,omitempty"`
+	Orientation          *Orientation           `json:"orientation,omitempty"`
+	OrientationOverrides map[string]Orientation `json:"orientation_overrides,omitempty"`
+	Margins              *string                `json:"margins,omitempty"`
+	Flow                 *Flow                  `json:"flow,omitempty"`
+	MaxPageHeight        *int                   `json:"max_page_height,omitempty"`
+	Density              *float64               `json:"density,omitempty"`
+	DeviceScaleFactor    *float64               `json:"device_scale_factor,omitempty"`
+	Zoom                 *float64               `json:"zoom,omitempty"`
+	Clip                 *ClipRect              `json:"clip,omitempty"`
+	Capture              *CaptureOptions        `json:"capture,omitempty"`
+	Thumbnail            *ThumbnailOptions      `json:"thumbnail,omitempty"`
+	Background           *string                `json:"background,omitempty"`
+	Transparent          *bool                  `json:"transparent,omitempty"`
+	Grayscale            *bool                  `json:"grayscale,omitempty"`
+	JavaScript           *bool                  `json:"javascript,omitempty"`
+	Styles               []string               `json:"styles,omitempty"`
+	Scripts              []string               `json:"scripts,omitempty"`
+	Wait                 *WaitOptions           `json:"wait,omitempty"`
+	Locale               *string                `json:"locale,omitempty"`
+	Timeout              *int                   `json:"timeout,omitempty"`
+	Media                *MediaType             `json:"media,omitempty"`
+	Engine               *Engine                `json:"engine,omitempty"`
+	WorkerAffinity       *string                `json:"worker_affinity,omitempty"`
+	Pages                *string                `json:"pages,omitempty"`
+	Quantize             *QuantizeOptions       `json:"quantize,omitempty"`
+	Image                *ImageOptions          `json:"image,omitempty"`
+	Pdf                  *PdfOptions            `json:"pdf,omitempty"`
+	Template             *TemplateOptions       `json:"template,omitempty"`
+	Email                *bool                  `json:"email,omitempty"`
+	TextLayout           *bool                  `json:"text_layout,omitempty"`
+	SplitPages           *bool                  `json:"split_pages,omitempty"`
+}
+
+// ClipRect is a page region in CSS pixels.
+type ClipRect struct {
+	X      float64 `json:"x"`
+	Y      float64 `json:"y"`
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+}
+
+// CaptureOptions selects the page element captured by an image render.
+type CaptureOptions struct {
+	Selector string `json:"selector"`
+	// Padding extends the element's bounding box on every side, in CSS pixels.
+	Padding *float64 `json:"padding,omitempty"`
+	// IncludeShadow extends the box to cover the element's box-shadow.
+	IncludeShadow *bool `json:"include_shadow,omitempty"`
+}
+
+// QuantizeOptions controls color quantization of image output.
+type QuantizeOptions struct {
+	Colors *int `json:"colors,omitempty"`
+	// Palette is either a Palette preset name or a []string of hex colors.
+	Palette any           `json:"palette,omitempty"`
+	Dither  *DitherMethod `json:"dither,omitempty"`
+}
+
+// PdfOptions controls PDF-specific output.
+type PdfOptions struct {
+	Title         *string             `json:"title,omitempty"`
+	Author        *string             `json:"author,omitempty"`
+	Subject       *string             `json:"subject,omitempty"`
+	Keywords      *string             `json:"keywords,omitempty"`
+	Creator       *string             `json:"creator,omitempty"`
+	Bookmarks     *bool               `json:"bookmarks,omitempty"`
+	PageNumbers   *bool               `json:"page_numbers,omitempty"`
+	PageNumbering *PageNumbering      `json:"page_numbering,omitempty"`
+	Watermark     *WatermarkOptions   `json:"watermark,omitempty"`
+	Standard      *PdfStandard        `json:"standard,omitempty"`
+	EmbeddedFiles []EmbeddedFile      `json:"embedded_files,omitempty"`
+	Barcodes      []BarcodeConfig     `json:"barcodes,omitempty"`
+	Mode          *PdfMode            `json:"mode,omitempty"`
+	Signature     *SignOptions        `json:"signature,omitempty"`
+	Encryption    *EncryptionOptions  `json:"encryption,omitempty"`
+	Accessibility *AccessibilityLevel `json:"accessibility,omitempty"`
+	Linearize     *bool               `json:"linearize,omitempty"`
+	DocumentLang  *string             `json:"document_lang,omitempty"`
+	Tagging       *TaggingOptions     `json:"tagging,omitempty"`
+	// ColorProfile is a base64-encoded ICC profile.
+	ColorProfile *string     `json:"color_profile,omitempty"`
+	OutputIntent *string     `json:"output_intent,omitempty"`
+	CMYK         *bool       `json:"cmyk,omitempty"`
+	Imposition   *Imposition `json:"imposition,omitempty"`
+	// Xmp holds rdf:Description elements merged into the XMP packet.
+	Xmp           *string           `json:"xmp,omitempty"`
+	XmpProperties map[string]string `json:"xmp_properties,omitempty"`
+	// CustomProperties are extra entries of the document Info dictionary.
+	CustomProperties map[string]string `json:"custom_properties,omitempty"`
+	ViewerPrefs      *ViewerPrefs      `json:"viewer_prefs,omitempty"`
+	OpenAction       *OpenAction       `json:"open_action,omitempty"`
+	// NamedDestinations turns element ids into named destinations.
+	NamedDestinations *bool        `json:"named_destinations,omitempty"`
+	Links             *LinkOptions `json:"links,omitempty"`
+	TOC               *TOCOptions  `json:"toc,omitempty"`
+	// BookmarkOutline customizes bookmarks enabled with Bookmarks.
+	BookmarkOutline *BookmarkOutline `json:"bookmark_outline,omitempty"`
+	// Forms turns HTML form controls into fillable AcroForm fields.
+	Forms *bool `json:"forms,omitempty"`
+}
+
+// WatermarkOptions describes a text or image watermark applied to PDF pages.
+type WatermarkOptions struct {
+	Text *string `json:"text,omitempty"`
+	// ImageData is a base64-encoded PNG/JPEG image.
+	ImageData *string         `json:"image_data,omitempty"`
+	Opacity   *float64        `json:"opacity,omitempty"`
+	Rotation  *float64        `json:"rotation,omitempty"`
+	Color     *string         `json:"color,omitempty"`
+	FontSize  *float64        `json:"font_size,omitempty"`
+	Scale     *float64        `json:"scale,omitempty"`
+	Layer     *WatermarkLayer `json:"layer,omitempty"`
+	Pages     *string         `json:"pages,omitempty"`
+}
+
+// SignOptions describes a digital signature applied to a PDF.
+type SignOptions struct {
+	// CertificateData is a base64-encoded PKCS#12 certificate.
+	CertificateData string `json:"certificate_data,omitempty"`
+	Password        string `json:"password,omitempty"`
+	SignerName      string `json:"signer_name,omitempty"`
+	Reason          string `json:"reason,omitempty"`
+	Location        string `json:"location,omitempty"`
+	// TimestampURL is an RFC 3161 timestamp server URL.
+	TimestampURL string `json:"timestamp_url,omitempty"`
+}
+
+// EncryptionOptions describes PDF password protection.
+type EncryptionOptions struct {
+	UserPassword  string `json:"user_password,omitempty"`
+	OwnerPassword string `json:"owner_password,omitempty"`
+	// Permissions is a comma-separated list of flags, e.g. "print,copy".
+	Permissions string `json:"permissions,omitempty"`
+}
+
+// TaggingOptions controls the structure tags screen readers use.
+type TaggingOptions struct {
+	// AltTextMap maps image sources, as written in the document's src
+	// attributes, to alternative text. It overrides alt attributes.
+	AltTextMap map[string]string `json:"alt_text_map,omitempty"`
+	// HeadingDetection tags text styled like a heading as one even without
+	// an h1-h6 element. When false, only h1-h6 elements are tagged as
+	// headings.
+	HeadingDetection bool `json:"heading_detection"`
+}
+
+// LinkOptions controls how hyperlinks appear in PDF output. All fields are
+// sent, so the zero value turns every link off. Without PdfLinks, internal
+// and external links are kept and no footnotes are added.
+type LinkOptions struct {
+	// Internal makes links to #anchors jump within the document.
+	Internal bool `json:"internal"`
+	// External keeps links to other documents clickable.
+	External bool `json:"external"`
+	// FootnoteURLs appends the URL of each external link as a footnote, for
+	// printed copies.
+	FootnoteURLs bool `json:"footnote_urls"`
+}
@@ -0,0 +1,186 @@
+package forge
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// PdfClient post-processes existing PDF documents. Obtain one with
+// Client.Pdf.
+type PdfClient struct {
+	c *Client
+}
+
+// Pdf returns the client for PDF post-processing calls.
+func (c *Client) Pdf() *PdfClient {
+	return &PdfClient{c: c}
+}
+
+// MergeDocument is one input document of a merge.
+type MergeDocument struct {
+	// Data is the PDF file content.
+	Data []byte `json:"data"`
+	// Bookmark adds a top-level outline entry pointing at the document's
+	// first page, and lists it in a generated table of contents.
+	Bookmark string `json:"bookmark,omitempty"`
+}
+
+// MergeTOC requests a generated table of contents in a merged PDF.
+type MergeTOC struct {
+	// Title heads the table of contents page (server default "Contents").
+	Title string `json:"title,omitempty"`
+	// Position is the index in Documents before which the table of
+	// contents is inserted, e.g. 1 to place it after a cover.
+	Position int `json:"position"`
+}
+
+// MergeRequest describes a merge of several PDFs into one.
+type MergeRequest struct {
+	Documents []MergeDocument `json:"documents"`
+	TOC       *MergeTOC       `json:"toc,omitempty"`
+}
+
+// Merge concatenates the documents in order and returns the merged PDF.
+func (p *PdfClient) Merge(ctx context.Context, req MergeRequest) ([]byte, error) {
+	if len(req.Documents) == 0 {
+		return nil, &ValidationError{Field: "documents", Message: "nothing to merge"}
+	}
+	for i, d := range req.Documents {
+		if err := checkPDF(fmt.Sprintf("documents[%d]", i), d.Data); err != nil {
+			return nil, err
+		}
+	}
+	if t := req.TOC; t != nil && (t.Position < 0 || t.Position > len(req.Documents)) {
+		return nil, &ValidationError{Field: "toc.position", Message: "out of range"}
+	}
+	return p.c.postJSON(ctx, "/pdf/merge", req)
+}
+
+// StampOptions lists the overlays Stamp applies to an existing PDF. They
+// behave as the corresponding render options.
+type StampOptions struct {
+	Watermark     *WatermarkOptions `json:"watermark,omitempty"`
+	Barcodes      []BarcodeConfig   `json:"barcodes,omitempty"`
+	PageNumbering *PageNumbering    `json:"page_numbering,omitempty"`
+}
+
+// Stamp applies watermarks, barcodes, and page numbers to an existing PDF,
+// such as a scanned or third-party document, without re-rendering it, and
+// returns the stamped PDF.
+func (p *PdfClient) Stamp(ctx context.Context, pdf []byte, opts StampOptions) ([]byte, error) {
+	if err := checkPDF("pdf", pdf); err != nil {
+		return nil, err
+	}
+	if opts.Watermark == nil && len(opts.Barcodes) == 0 && opts.PageNumbering == nil {
+		return nil, &ValidationError{Field: "stamp", Message: "nothing to apply"}
+	}
+	for _, b := range opts.Barcodes {
+		if err := b.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	return p.c.postJSON(ctx, "/pdf/stamp", struct {
+		PDF []byte `json:"pdf"`
+		StampOptions
+	}{pdf, opts})
+}
+
+// OptimizeOptions controls how Optimize shrinks a PDF. Zero fields leave
+// that aspect unchanged.
+type OptimizeOptions struct {
+	// ImageDPI downsamples images above this resolution, e.g. 150 for
+	// on-screen reading.
+	ImageDPI int `json:"image_dpi,omitempty"`
+	// ImageQuality recompresses images as JPEG at this quality, 1-100.
+	ImageQuality int `json:"image_quality,omitempty"`
+	// RemoveUnused drops unreferenced objects, duplicate resources, and
+	// unused font glyphs.
+	RemoveUnused bool `json:"remove_unused,omitempty"`
+}
+
+// Optimize shrinks a PDF, e.g. before emailing it, and returns the
+// optimized PDF.
+func (p *PdfClient) Optimize(ctx context.Context, pdf []byte, opts OptimizeOptions) ([]byte, error) {
+	if err := checkPDF("pdf", pdf); err != nil {
+		return nil, err
+	}
+	if opts.ImageDPI < 0 {
+		return nil, &ValidationError{Field: "image_dpi", Message: "must not be negative"}
+	}
+	if opts.ImageQuality < 0 || opts.ImageQuality > 100 {
+		return nil, &ValidationError{Field: "image_quality", Message: "must be between 1 and 100"}
+	}
+	return p.c.postJSON(ctx, "/pdf/optimize", struct {
+		PDF []byte `json:"pdf"`
+		OptimizeOptions
+	}{pdf, opts})
+}
+
+// Sign digitally signs an existing PDF, such as one received from another
+// system, with the same options as render-time signing, and returns the
+// signed PDF. Existing signatures are preserved.
+func (p *PdfClient) Sign(ctx context.Context, pdf []byte, opts SignOptions) ([]byte, error) {
+	if err := checkPDF("pdf", pdf); err != nil {
+		return nil, err
+	}
+	if opts.CertificateData == "" {
+		return nil, &ValidationError{Field: "signature.certificate_data", Message: "is required"}
+	}
+	return p.c.postJSON(ctx, "/pdf/sign", struct {
+		PDF       []byte      `json:"pdf"`
+		Signature SignOptions `json:"signature"`
+	}{pdf, opts})
+}
+
+// pdfImageFormats are the formats ToImages converts to.
+var pdfImageFormats = map[OutputFormat]bool{FormatPNG: true, FormatJPEG: true, FormatWebP: true, FormatTIFF: true}
+
+// ToImages rasterizes the pages of an existing PDF to PNG, JPEG, WebP, or
+// TIFF images at dpi and returns one Page per page. pages is a page spec
+// such as "1,3-5"; empty converts every page.
+func (p *PdfClient) ToImages(ctx context.Context, pdf []byte, format OutputFormat, dpi float64, pages string) ([]Page, error) {
+	if err := checkPDF("pdf", pdf); err != nil {
+		return nil, err
+	}
+	if !pdfImageFormats[format] {
+		return nil, &ValidationError{Field: "format", Message: fmt.Sprintf("cannot convert PDF pages to %q", format)}
+	}
+	if !(dpi > 0) {
+		return nil, &ValidationError{Field: "dpi", Message: fmt.Sprintf("must be positive, got %v", dpi)}
+	}
+	if pages != "" {
+		if _, err := parsePageRanges(pages); err != nil {
+			return nil, &ValidationError{Field: "pages", Message: err.Error()}
+		}
+	}
+
+	body, err := json.Marshal(struct {
+		PDF    []byte       `json:"pdf"`
+		Format OutputFormat `json:"format"`
+		DPI    float64      `json:"dpi"`
+		Pages  string       `json:"pages,omitempty"`
+	}{pdf, format, dpi, pages})
+	if err != nil {
+		return nil, fmt.Errorf("forge: marshal error: %w", err)
+	}
+	req, err := p.c.newRequest(ctx, http.MethodPost, "/pdf/images", body, "application/json")
+	if err != nil {
+		return nil, fmt.Errorf("forge: request error: %w", err)
+	}
+	resp, x, err := p.c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	return readPages(resp, x)
+}
+
+// checkPDF rejects data that is not a PDF file.
+func checkPDF(field string, data []byte) error {
+	if !bytes.HasPrefix(data, []byte("%PDF")) {
+		return &ValidationError{Field: field, Message: "not a PDF document"}
+	}
+	return nil
+}
@@ -0,0 +1,254 @@
+package forge
+
+import (
+	"context"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// pdfServer answers POST path with resp and records the decoded request.
+func pdfServer(t *testing.T, path, resp string, got *map[string]any) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != path {
+			t.Errorf("request = %s %s", r.Method, r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(got)
+		w.Write([]byte(resp))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestPdfStamp(t *testing.T) {
+	var got map[string]any
+	srv := pdfServer(t, "/pdf/stamp", "%PDF-stamped", &got)
+	text := "COPY"
+	out, err := NewClient(srv.URL).Pdf().Stamp(context.Background(), []byte("%PDF-1.7"), StampOptions{
+		Watermark:     &WatermarkOptions{Text: &text},
+		Barcodes:      []BarcodeConfig{{Type: BarcodeQR, Data: "INV-1"}},
+		PageNumbering: &PageNumbering{Start: 2},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != "%PDF-stamped" {
+		t.Errorf("out = %q", out)
+	}
+	want := map[string]any{
+		"pdf":            "JVBERi0xLjc=",
+		"watermark":      map[string]any{"text": "COPY"},
+		"barcodes":       []any{map[string]any{"type": "qr", "data": "INV-1"}},
+		"page_numbering": map[string]any{"start": float64(2)},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("request = %v", got)
+	}
+
+	pdf := NewClient(srv.URL).Pdf()
+	for _, tc := range []struct {
+		data  string
+		opts  StampOptions
+		field string
+	}{
+		{"<html>", StampOptions{PageNumbering: &PageNumbering{}}, "pdf"},
+		{"%PDF-1.7", StampOptions{}, "stamp"},
+		{"%PDF-1.7", StampOptions{Barcodes: []BarcodeConfig{{Type: BarcodeEAN13, Data: "x"}}}, "barcode.data"},
+	} {
+		_, err := pdf.Stamp(context.Background(), []byte(tc.data), tc.opts)
+		if ve, ok := err.(*ValidationError); !ok || ve.Field != tc.field {
+			t.Errorf("err = %v, want field %s", err, tc.field)
+		}
+	}
+}
+
+func TestPdfMergeValidation(t *testing.T) {
+	pdf := NewClient("http://localhost:3000").Pdf()
+	for _, tc := range []struct {
+		req   MergeRequest
+		field string
+	}{
+		{MergeRequest{}, "documents"},
+		{MergeRequest{Documents: []MergeDocument{{Data: []byte("%PDF-1.7")}, {Data: []byte("<html>")}}}, "documents[1]"},
+		{MergeRequest{Documents: []MergeDocument{{Data: []byte("%PDF-1.7")}}, TOC: &MergeTOC{Position: 2}}, "toc.position"},
+	} {
+		_, err := pdf.Merge(context.Background(), tc.req)
+		if ve, ok := err.(*ValidationError); !ok || ve.Field != tc.field {
+			t.Errorf("err = %v, want field %s", err, tc.field)
+		}
+	}
+}
+
+func TestPdfImpose(t *testing.T) {
+	var got map[string]any
+	srv := pdfServer(t, "/pdf/impose", "%PDF-imposed", &got)
+	pdf := NewClient(srv.URL).Pdf()
+	if _, err := pdf.Impose(context.Background(), []byte("%PDF-1.7"), Booklet); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{"pdf": "JVBERi0xLjc=", "imposition": map[string]any{"layout": "booklet"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("request = %v", got)
+	}
+
+	if _, err := pdf.Impose(context.Background(), []byte("%PDF-1.7"), NUp(3)); err == nil {
+		t.Error("NUp(3) accepted")
+	}
+	if _, err := pdf.Impose(context.Background(), []byte("%PDF-1.7"), Imposition{}); err == nil {
+		t.Error("zero Imposition accepted")
+	}
+}
+
+func TestPdfImposition(t *testing.T) {
+	c := NewClient("http://localhost:3000")
+	p := payloadMap(t, c.RenderHTML("x").PdfImposition(NUp(4)))
+	want := map[string]any{"layout": "n-up", "pages": float64(4)}
+	if got := p["pdf"].(map[string]any)["imposition"]; !reflect.DeepEqual(got, want) {
+		t.Errorf("imposition = %v", got)
+	}
+	if ve, ok := c.RenderHTML("x").PdfImposition(NUp(5)).validate().(*ValidationError); !ok || ve.Field != "pdf.imposition" {
+		t.Error("NUp(5) accepted")
+	}
+}
+
+func TestPdfOptimize(t *testing.T) {
+	var got map[string]any
+	srv := pdfServer(t, "/pdf/optimize", "%PDF-small", &got)
+	pdf := NewClient(srv.URL).Pdf()
+	out, err := pdf.Optimize(context.Background(), []byte("%PDF-1.7"), OptimizeOptions{ImageDPI: 150, ImageQuality: 80, RemoveUnused: true})
+	if err != nil || string(out) != "%PDF-small" {
+		t.Fatalf("Optimize = %q, %v", out, err)
+	}
+	want := map[string]any{"pdf": "JVBERi0xLjc=", "image_dpi": float64(150), "image_quality": float64(80), "remove_unused": true}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("request = %v", got)
+	}
+
+	for _, opts := range []OptimizeOptions{{ImageDPI: -1}, {ImageQuality: 101}} {
+		if _, err := pdf.Optimize(context.Background(), []byte("%PDF-1.7"), opts); err == nil {
+			t.Errorf("%+v accepted", opts)
+		}
+	}
+}
+
+func TestPdfToImages(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var got map[string]any
+		json.NewDecoder(r.Body).Decode(&got)
+		want := map[string]any{"pdf": "JVBERi0xLjc=", "format": "jpeg", "dpi": float64(150), "pages": "2-3"}
+		if r.URL.Path != "/pdf/images" || !reflect.DeepEqual(got, want) {
+			t.Errorf("request = %s %v", r.URL.Path, got)
+		}
+		mw := multipart.NewWriter(w)
+		w.Header().Set("Content-Type", "multipart/mixed; boundary="+mw.Boundary())
+		for _, n := range []string{"2", "3"} {
+			part, _ := mw.CreatePart(textproto.MIMEHeader{"Content-Type": {"image/jpeg"}, "X-Forge-Page": {n}})
+			part.Write([]byte("jpeg" + n))
+		}
+		mw.Close()
+	}))
+	defer srv.Close()
+	pdf := NewClient(srv.URL).Pdf()
+
+	pages, err := pdf.ToImages(context.Background(), []byte("%PDF-1.7"), FormatJPEG, 150, "2-3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pages) != 2 || pages[0].Number != 2 || string(pages[1].Data) != "jpeg3" || pages[1].ContentType != "image/jpeg" {
+		t.Errorf("pages = %+v", pages)
+	}
+
+	for _, tc := range []struct {
+		format OutputFormat
+		dpi    float64
+		pages  string
+		field  string
+	}{
+		{FormatPDF, 150, "", "format"},
+		{FormatPNG, 0, "", "dpi"},
+		{FormatPNG, 150, "2-x", "pages"},
+	} {
+		_, err := pdf.ToImages(context.Background(), []byte("%PDF-1.7"), tc.format, tc.dpi, tc.pages)
+		if ve, ok := err.(*ValidationError); !ok || ve.Field != tc.field {
+			t.Errorf("err = %v, want field %s", err, tc.field)
+		}
+	}
+}
+
+func TestPdfSign(t *testing.T) {
+	var got map[string]any
+	srv := pdfServer(t, "/pdf/sign", "%PDF-signed", &got)
+	pdf := NewClient(srv.URL).Pdf()
+	if _, err := pdf.Sign(context.Background(), []byte("%PDF-1.7"), SignOptions{CertificateData: "Y2VydA==", Reason: "Archive"}); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{"pdf": "JVBERi0xLjc=", "signature": map[string]any{"certificate_data": "Y2VydA==", "reason": "Archive"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("request = %v", got)
+	}
+	if _, err := pdf.Sign(context.Background(), []byte("%PDF-1.7"), SignOptions{SignerName: "x"}); err == nil {
+		t.Error("Sign without a certificate accepted")
+	}
+}
+
+func TestPdfVerifySignatures(t *testing.T) {
+	var got map[string]any
+	srv := pdfServer(t, "/pdf/signatures/verify", `{"signatures":[
+		{"signer_name":"Jane Roe","signed_at":"2026-03-04T05:06:07Z","timestamped":true,
+		 "chain":[{"subject":"CN=Jane Roe","issuer":"CN=Acme CA","not_before":"2026-01-01T00:00:00Z","not_after":"2027-01-01T00:00:00Z","der":"MAA="}],
+		 "covers_document":true,"valid":true},
+		{"signer_name":"Mallory","signed_at":"2026-03-05T00:00:00Z","chain":[],"covers_document":false,"valid":false,"problems":["document modified after signing"]}
+	]}`, &got)
+	sigs, err := NewClient(srv.URL).Pdf().VerifySignatures(context.Background(), []byte("%PDF-1.7"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got["pdf"] != "JVBERi0xLjc=" {
+		t.Errorf("request = %v", got)
+	}
+	if len(sigs) != 2 {
+		t.Fatalf("signatures = %+v", sigs)
+	}
+	s := sigs[0]
+	if s.SignerName != "Jane Roe" || !s.Valid || !s.Timestamped || len(s.Chain) != 1 || s.Chain[0].Issuer != "CN=Acme CA" || string(s.Chain[0].DER) != "0\x00" {
+		t.Errorf("signature 0 = %+v", s)
+	}
+	if !s.SignedAt.Equal(time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)) {
+		t.Errorf("SignedAt = %v", s.SignedAt)
+	}
+	if sigs[1].Valid || len(sigs[1].Problems) != 1 {
+		t.Errorf("signature 1 = %+v", sigs[1])
+	}
+}
+
+func TestPdfValidate(t *testing.T) {
+	var got map[string]any
+	srv := pdfServer(t, "/pdf/validate", `{"standard":"pdf/a-3b","compliant":false,"violations":[
+		{"rule":"ISO 19005-3:6.2.11.4.1","message":"font not embedded","page":2,"count":3}]}`, &got)
+	pdf := NewClient(srv.URL).Pdf()
+	report, err := pdf.Validate(context.Background(), []byte("%PDF-1.7"), PdfStandardA3B)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got["standard"] != "pdf/a-3b" {
+		t.Errorf("request = %v", got)
+	}
+	if report.Compliant || len(report.Violations) != 1 {
+		t.Fatalf("report = %+v", report)
+	}
+	if s := report.Violations[0].String(); s != "ISO 19005-3:6.2.11.4.1: font not embedded (page 2, 3 occurrences)" {
+		t.Errorf("violation = %q", s)
+	}
+	if _, err := pdf.Validate(context.Background(), []byte("%PDF-1.7"), PdfStandardNone); err == nil {
+		t.Error("PdfStandardNone accepted")
+	}
+	if err := NewClient(srv.URL).RenderHTML("x").PdfStandard(PdfStandardUA1).validate(); err == nil {
+		t.Error("PdfStandard(PdfStandardUA1) accepted")
+	}
+}
@@ -0,0 +1,60 @@
+package forge
+
+import (
+	"fmt"
+	"mime"
+	"path"
+)
+
+// pdfaLevel lists what a PDF/A conformance level forbids.
+type pdfaLevel struct {
+	name string
+	// transparency is false for PDF/A-1, which forbids transparent
+	// content such as watermarks.
+	transparency bool
+	// attachments is false for PDF/A-1, which forbids embedded files.
+	attachments bool
+	// pdfAttachmentsOnly is true for PDF/A-2, which allows only embedded
+	// PDF/A documents.
+	pdfAttachmentsOnly bool
+}
+
+var pdfaLevels = map[PdfStandard]pdfaLevel{
+	PdfStandardA1B: {name: "PDF/A-1b"},
+	PdfStandardA2B: {name: "PDF/A-2b", transparency: true, attachments: true, pdfAttachmentsOnly: true},
+	PdfStandardA2U: {name: "PDF/A-2u", transparency: true, attachments: true, pdfAttachmentsOnly: true},
+	PdfStandardA3B: {name: "PDF/A-3b", transparency: true, attachments: true},
+	PdfStandardA3U: {name: "PDF/A-3u", transparency: true, attachments: true},
+	PdfStandardA4:  {name: "PDF/A-4", transparency: true, attachments: true},
+}
+
+// validatePdfStandard rejects options the chosen PDF/A level forbids.
+func (r *RenderRequest) validatePdfStandard() error {
+	pdf := r.p.Pdf
+	if pdf == nil || pdf.Standard == nil {
+		return nil
+	}
+	level, ok := pdfaLevels[*pdf.Standard]
+	if !ok {
+		return nil
+	}
+	if pdf.Encryption != nil {
+		return &ValidationError{Field: "pdf.encryption", Message: level.name + " forbids encryption"}
+	}
+	if !level.transparency && pdf.Watermark != nil {
+		return &ValidationError{Field: "pdf.watermark", Message: level.name + " forbids transparency, which watermarks use"}
+	}
+	for _, ef := range pdf.EmbeddedFiles {
+		if !level.attachments {
+			return &ValidationError{Field: "pdf.embedded_files", Message: level.name + " forbids embedded files"}
+		}
+		mimeType := ef.MimeType
+		if mimeType == "" {
+			mimeType = mime.TypeByExtension(path.Ext(ef.Path))
+		}
+		if level.pdfAttachmentsOnly && mimeType != "application/pdf" {
+			return &ValidationError{Field: "pdf.embedded_files", Message: fmt.Sprintf("%s allows only embedded PDF/A documents, not %q; use PDF/A-3", level.name, ef.Path)}
+		}
+	}
+	return nil
+}
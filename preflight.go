@@ -0,0 +1,64 @@
+package forge
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// PreflightReport is the result of checking a PDF against a standard.
+type PreflightReport struct {
+	Standard PdfStandard `json:"standard"`
+	// Compliant reports whether the document has no violations.
+	Compliant  bool                 `json:"compliant"`
+	Violations []PreflightViolation `json:"violations,omitempty"`
+}
+
+// PreflightViolation is one conformance failure found by Validate.
+type PreflightViolation struct {
+	// Rule identifies the violated requirement, e.g. "ISO 19005-2:6.2.11.4.1".
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+	// Page is the 1-based page of the first occurrence; 0 for
+	// document-level violations.
+	Page int `json:"page,omitempty"`
+	// Count is the number of occurrences.
+	Count int `json:"count"`
+}
+
+func (v PreflightViolation) String() string {
+	if v.Page > 0 {
+		return fmt.Sprintf("%s: %s (page %d, %d occurrences)", v.Rule, v.Message, v.Page, v.Count)
+	}
+	return fmt.Sprintf("%s: %s (%d occurrences)", v.Rule, v.Message, v.Count)
+}
+
+// Validate checks a PDF against an archival or accessibility standard,
+// e.g. PdfStandardA2B or PdfStandardUA1, and reports every violation. A
+// non-compliant document is reported through the PreflightReport, not as
+// an error, so CI pipelines can assert on it:
+//
+//	report, err := client.Pdf().Validate(ctx, pdf, forge.PdfStandardA3B)
+//	if err == nil && !report.Compliant {
+//		t.Errorf("not PDF/A-3b: %v", report.Violations)
+//	}
+func (p *PdfClient) Validate(ctx context.Context, pdf []byte, standard PdfStandard) (*PreflightReport, error) {
+	if err := checkPDF("pdf", pdf); err != nil {
+		return nil, err
+	}
+	if standard == "" || standard == PdfStandardNone {
+		return nil, &ValidationError{Field: "standard", Message: "a standard to validate against is required"}
+	}
+	data, err := p.c.postJSON(ctx, "/pdf/validate", struct {
+		PDF      []byte      `json:"pdf"`
+		Standard PdfStandard `json:"standard"`
+	}{pdf, standard})
+	if err != nil {
+		return nil, err
+	}
+	var report PreflightReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("forge: decode preflight report: %w", err)
+	}
+	return &report, nil
+}
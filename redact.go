@@ -0,0 +1,129 @@
+package forge
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// redacted replaces secret values in redacted payloads.
+const redacted = "[REDACTED]"
+
+// redactPayload returns a copy of p with secrets replaced and base64 blobs
+// summarized, safe to write to logs or tickets. p is not modified.
+func redactPayload(p *RenderPayload) *RenderPayload {
+	c, _ := redactSecrets(p)
+	if len(c.Assets) > 0 {
+		assets := make([]Asset, len(c.Assets))
+		for i, a := range c.Assets {
+			a.Data = []byte(blobSummary(len(a.Data)))
+			assets[i] = a
+		}
+		c.Assets = assets
+	}
+	if c.Archive != nil {
+		c.Archive = &ArchiveSource{Entry: c.Archive.Entry, Data: []byte(blobSummary(len(c.Archive.Data)))}
+	}
+	if c.Pdf == nil {
+		return c
+	}
+
+	pdf := *c.Pdf
+	if pdf.Watermark != nil && pdf.Watermark.ImageData != nil {
+		wm := *pdf.Watermark
+		summary := blobSummary(len(*wm.ImageData))
+		wm.ImageData = &summary
+		pdf.Watermark = &wm
+	}
+	if pdf.ColorProfile != nil {
+		summary := blobSummary(len(*pdf.ColorProfile))
+		pdf.ColorProfile = &summary
+	}
+	if len(pdf.EmbeddedFiles) > 0 {
+		files := make([]EmbeddedFile, len(pdf.EmbeddedFiles))
+		for i, ef := range pdf.EmbeddedFiles {
+			ef.Data = blobSummary(len(ef.Data))
+			files[i] = ef
+		}
+		pdf.EmbeddedFiles = files
+	}
+	c.Pdf = &pdf
+	return c
+}
+
+// redactSecrets returns a copy of p with cookie values, credential page
+// headers, login form values, proxy passwords, and signing and encryption
+// secrets replaced, and whether any were found. Unlike redactPayload it
+// keeps all document content. p is not modified.
+func redactSecrets(p *RenderPayload) (*RenderPayload, bool) {
+	c := *p
+	found := false
+	if len(p.Cookies) > 0 {
+		c.Cookies = make([]PageCookie, len(p.Cookies))
+		for i, ck := range p.Cookies {
+			found = redactString(&ck.Value) || found
+			c.Cookies[i] = ck
+		}
+	}
+	if len(p.PageHeaders) > 0 {
+		c.PageHeaders = make(map[string]string, len(p.PageHeaders))
+		for k, v := range p.PageHeaders {
+			if isCredentialHeader(k) {
+				found = redactString(&v) || found
+			}
+			c.PageHeaders[k] = v
+		}
+	}
+	if p.PageProxy != nil {
+		if u, err := url.Parse(*p.PageProxy); err == nil && u.User != nil {
+			if _, ok := u.User.Password(); ok {
+				u.User = url.UserPassword(u.User.Username(), redacted)
+				proxy := u.String()
+				c.PageProxy = &proxy
+				found = true
+			}
+		}
+	}
+	if p.Login != nil {
+		login := *p.Login
+		login.Steps = make([]LoginStep, len(p.Login.Steps))
+		for i, s := range p.Login.Steps {
+			if s.Action == LoginActionFill {
+				found = redactString(&s.Value) || found
+			}
+			login.Steps[i] = s
+		}
+		c.Login = &login
+	}
+	if p.Pdf == nil {
+		return &c, found
+	}
+
+	pdf := *p.Pdf
+	if pdf.Signature != nil {
+		sig := *pdf.Signature
+		found = redactString(&sig.CertificateData) || found
+		found = redactString(&sig.Password) || found
+		pdf.Signature = &sig
+	}
+	if pdf.Encryption != nil {
+		enc := *pdf.Encryption
+		found = redactString(&enc.UserPassword) || found
+		found = redactString(&enc.OwnerPassword) || found
+		pdf.Encryption = &enc
+	}
+	c.Pdf = &pdf
+	return &c, found
+}
+
+// redactString replaces a non-empty *s and reports whether it did.
+func redactString(s *string) bool {
+	if *s == "" {
+		return false
+	}
+	*s = redacted
+	return true
+}
+
+func blobSummary(n int) string {
+	return fmt.Sprintf("[base64, %d bytes]", n)
+}
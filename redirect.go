@@ -0,0 +1,57 @@
+package forge
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// RedirectPolicy controls how the client handles 3xx responses from the server.
+type RedirectPolicy int
+
+const (
+	// RedirectPolicyFollow follows redirects, re-sending the request body
+	// for 307/308. This is the default.
+	RedirectPolicyFollow RedirectPolicy = iota
+	// RedirectPolicyError fails the request with a *RedirectError.
+	RedirectPolicyError
+	// RedirectPolicyManual does not follow redirects. Render calls report the
+	// 3xx response as a *ServerError.
+	RedirectPolicyManual
+)
+
+// WithRedirectPolicy sets how the client handles 3xx responses.
+func WithRedirectPolicy(p RedirectPolicy) Option {
+	return func(c *Client) {
+		c.redirect = p
+	}
+}
+
+// RedirectError is returned under RedirectPolicyError when the server
+// responds with a redirect.
+type RedirectError struct {
+	StatusCode int
+	Location   string
+	// Attempts lists every HTTP attempt made, including retries.
+	Attempts []Attempt
+	// Elapsed is the total time across all attempts and backoff delays.
+	Elapsed time.Duration
+}
+
+func (e *RedirectError) Error() string {
+	return fmt.Sprintf("forge: unexpected redirect (%d) to %s", e.StatusCode, e.Location)
+}
+
+// applyRedirectPolicy stops the HTTP client from following redirects on a
+// copy of the client, leaving any caller-supplied client untouched.
+func (c *Client) applyRedirectPolicy() {
+	hc := *c.httpClient
+	hc.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	c.httpClient = &hc
+}
+
+func isRedirect(status int) bool {
+	return status >= 300 && status < 400 && status != http.StatusNotModified
+}
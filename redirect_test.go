@@ -0,0 +1,71 @@
+package forge
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func redirectServer(t *testing.T, hits *atomic.Int32) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/render", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/green/render", http.StatusTemporaryRedirect)
+	})
+	mux.HandleFunc("/green/render", func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.Write([]byte("%PDF"))
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestRedirectPolicyFollow(t *testing.T) {
+	var hits atomic.Int32
+	srv := redirectServer(t, &hits)
+
+	data, err := NewClient(srv.URL).RenderHTML("<p>x</p>").Send(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "%PDF" || hits.Load() != 1 {
+		t.Errorf("data = %q, hits = %d", data, hits.Load())
+	}
+}
+
+func TestRedirectPolicyError(t *testing.T) {
+	var hits atomic.Int32
+	srv := redirectServer(t, &hits)
+
+	c := NewClient(srv.URL, WithRedirectPolicy(RedirectPolicyError))
+	_, err := c.RenderHTML("<p>x</p>").Send(context.Background())
+	var re *RedirectError
+	if !errors.As(err, &re) {
+		t.Fatalf("err = %v, want *RedirectError", err)
+	}
+	if re.StatusCode != http.StatusTemporaryRedirect || re.Location != "/green/render" {
+		t.Errorf("err = %+v", re)
+	}
+	if hits.Load() != 0 {
+		t.Error("redirect target should not be requested")
+	}
+}
+
+func TestRedirectPolicyManual(t *testing.T) {
+	var hits atomic.Int32
+	srv := redirectServer(t, &hits)
+
+	c := NewClient(srv.URL, WithRedirectPolicy(RedirectPolicyManual))
+	_, err := c.RenderHTML("<p>x</p>").Send(context.Background())
+	var se *ServerError
+	if !errors.As(err, &se) || se.StatusCode != http.StatusTemporaryRedirect {
+		t.Fatalf("err = %v, want 307 *ServerError", err)
+	}
+	if hits.Load() != 0 {
+		t.Error("redirect target should not be requested")
+	}
+}
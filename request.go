@@ -0,0 +1,222 @@
+package forge
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// newRequest builds an HTTP request against the server. Bodies above the
+// client's compression threshold are gzip-encoded.
+func (c *Client) newRequest(ctx context.Context, method, path string, body []byte, contentType string) (*http.Request, error) {
+	return c.newRequestAt(ctx, c.baseURL, method, path, body, contentType)
+}
+
+// newRequestAt is newRequest against the server at base.
+func (c *Client) newRequestAt(ctx context.Context, base, method, path string, body []byte, contentType string) (*http.Request, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	var rd io.Reader
+	gzipped := false
+	if body != nil {
+		if c.compress && len(body) > c.compressThreshold {
+			var buf bytes.Buffer
+			zw := gzip.NewWriter(&buf)
+			if _, err := zw.Write(body); err != nil {
+				return nil, err
+			}
+			if err := zw.Close(); err != nil {
+				return nil, err
+			}
+			body = buf.Bytes()
+			gzipped = true
+		}
+		rd = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, base+path, rd)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "forge-sdk-go/"+Version)
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if gzipped {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
+	if c.locale != "" {
+		req.Header.Set("Accept-Language", c.locale)
+	}
+	if c.apiVersion != "" {
+		req.Header.Set("X-Forge-API-Version", c.apiVersion)
+	}
+	return req, nil
+}
+
+// postJSON sends v as JSON to path and returns the body of a 200 response.
+// Other statuses are returned as *ServerError.
+func (c *Client) postJSON(ctx context.Context, path string, v any) ([]byte, error) {
+	return c.sendJSON(ctx, http.MethodPost, path, v)
+}
+
+// sendJSON is postJSON with any method.
+func (c *Client) sendJSON(ctx context.Context, method, path string, v any) ([]byte, error) {
+	req, err := c.newJSONRequest(ctx, method, path, v)
+	if err != nil {
+		return nil, err
+	}
+	return c.call(req)
+}
+
+// newJSONRequest returns a request with v marshaled as its JSON body.
+func (c *Client) newJSONRequest(ctx context.Context, method, path string, v any) (*http.Request, error) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("forge: marshal error: %w", err)
+	}
+	req, err := c.newRequest(ctx, method, path, body, "application/json")
+	if err != nil {
+		return nil, fmt.Errorf("forge: request error: %w", err)
+	}
+	return req, nil
+}
+
+// call executes req and returns the body of a 200 response. Other statuses
+// are returned as *ServerError.
+func (c *Client) call(req *http.Request) ([]byte, error) {
+	resp, x, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	return readResponse(resp, x)
+}
+
+// callAdmin is call for the font and template admin endpoints, which may
+// answer with any 2xx status, such as 201 Created or 204 No Content.
+func (c *Client) callAdmin(req *http.Request) ([]byte, error) {
+	resp, x, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	return readBody(resp, x, resp.StatusCode >= 200 && resp.StatusCode < 300)
+}
+
+// readResponse reads and closes the body of resp, returning it for a 200
+// response and a *ServerError otherwise.
+func readResponse(resp *http.Response, x *exchange) ([]byte, error) {
+	return readBody(resp, x, resp.StatusCode == http.StatusOK)
+}
+
+// readBody reads and closes the body of resp, returning it if ok and a
+// *ServerError otherwise.
+func readBody(resp *http.Response, x *exchange, ok bool) ([]byte, error) {
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("forge: read body: %w", err)
+	}
+	if !ok {
+		se := newServerError(resp.StatusCode, data)
+		se.Attempts, se.Elapsed = x.attempts, x.elapsed()
+		return nil, se
+	}
+	return data, nil
+}
+
+// do executes req, retrying per the client's RetryPolicy and recording
+// client metrics. The returned exchange lists every attempt made. Transport
+// failures are returned as *ConnectionError.
+func (c *Client) do(req *http.Request) (*http.Response, *exchange, error) {
+	x := &exchange{start: time.Now()}
+	ctx := req.Context()
+	replayable := req.Body == nil || req.GetBody != nil
+	for n := 1; ; n++ {
+		attemptReq := req
+		if n > 1 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, x, err
+			}
+			attemptReq = req.Clone(ctx)
+			attemptReq.Body = body
+		}
+
+		start := time.Now()
+		c.metrics.begin(attemptReq.ContentLength)
+		resp, err := c.httpClient.Do(attemptReq)
+		d := time.Since(start)
+		c.metrics.end(d, resp, err)
+
+		a := Attempt{Err: err, Duration: d}
+		if resp != nil {
+			a.StatusCode = resp.StatusCode
+		}
+		x.attempts = append(x.attempts, a)
+
+		if !replayable || ctx.Err() != nil || !c.retry.shouldRetry(n, resp, err) {
+			if err != nil {
+				return nil, x, &ConnectionError{Cause: err, Attempts: x.attempts, Elapsed: x.elapsed()}
+			}
+			if c.redirect == RedirectPolicyError && isRedirect(resp.StatusCode) {
+				resp.Body.Close()
+				return nil, x, &RedirectError{
+					StatusCode: resp.StatusCode,
+					Location:   resp.Header.Get("Location"),
+					Attempts:   x.attempts,
+					Elapsed:    x.elapsed(),
+				}
+			}
+			return resp, x, nil
+		}
+
+		delay := c.retry.delay(n, resp)
+		if resp != nil {
+			io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+			resp.Body.Close()
+		}
+		if err := sleep(ctx, delay); err != nil {
+			return nil, x, &ConnectionError{Cause: err, Attempts: x.attempts, Elapsed: x.elapsed()}
+		}
+	}
+}
+
+// newRenderResponse builds a RenderResponse from a successful render's
+// headers and body.
+func newRenderResponse(h http.Header, data []byte, x *exchange) *RenderResponse {
+	res := &RenderResponse{
+		Attempts:    x.attempts,
+		Elapsed:     x.elapsed(),
+		Data:        data,
+		Warnings:    h.Values("X-Forge-Warning"),
+		Engine:      h.Get("X-Forge-Engine"),
+		CacheStatus: CacheStatus(strings.ToUpper(h.Get("X-Forge-Cache"))),
+		WorkerID:    h.Get("X-Forge-Worker"),
+		Locale:      h.Get("Content-Language"),
+	}
+	// X-Forge-Render-Time is in milliseconds and may be fractional.
+	if ms, err := strconv.ParseFloat(h.Get("X-Forge-Render-Time"), 64); err == nil && ms >= 0 {
+		res.RenderDuration = time.Duration(ms * float64(time.Millisecond))
+	}
+	return res
+}
+
+// resourcePath returns the API path of the named resource in collection,
+// e.g. "/fonts/Inter.ttf". Names that are empty or would escape the
+// collection are reported against field.
+func resourcePath(collection, field, what, name string) (string, error) {
+	if name == "" || strings.ContainsAny(name, "/\\") || name == "." || name == ".." {
+		return "", &ValidationError{Field: field, Message: fmt.Sprintf("invalid %s %q", what, name)}
+	}
+	return collection + "/" + url.PathEscape(name), nil
+}
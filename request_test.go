@@ -0,0 +1,602 @@
+package forge
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCompressionAboveThreshold(t *testing.T) {
+	html := strings.Repeat("<p>row</p>", 1000)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Encoding") != "gzip" {
+			t.Errorf("Content-Encoding = %q, want gzip", r.Header.Get("Content-Encoding"))
+		}
+		zr, err := gzip.NewReader(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var p map[string]any
+		if err := json.NewDecoder(zr).Decode(&p); err != nil {
+			t.Fatal(err)
+		}
+		if p["html"] != html {
+			t.Error("decompressed html does not match")
+		}
+		w.Write([]byte("%PDF"))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, WithCompression(1024))
+	data, err := c.RenderHTML(html).Send(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "%PDF" {
+		t.Errorf("data = %q", data)
+	}
+}
+
+func TestCompressionBelowThreshold(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if enc := r.Header.Get("Content-Encoding"); enc != "" {
+			t.Errorf("Content-Encoding = %q, want none", enc)
+		}
+		body, _ := io.ReadAll(r.Body)
+		if !json.Valid(body) {
+			t.Errorf("body is not plain JSON: %q", body)
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, WithCompression(1024))
+	if _, err := c.RenderHTML("<p>small</p>").Send(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRenderResponseHeaders(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("X-Forge-Warning", "grid-template-areas is not supported")
+		w.Header().Set("X-Forge-Engine", "forge/2.4.1")
+		w.Header().Set("X-Forge-Cache", "hit")
+		w.Header().Set("X-Forge-Worker", "worker-7")
+		w.Header().Set("X-Forge-Render-Time", "182.5")
+		w.Write([]byte("%PDF"))
+	}))
+	defer srv.Close()
+
+	res, err := NewClient(srv.URL).RenderHTML("<p>x</p>").SendWithWarnings(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Warnings) != 1 {
+		t.Errorf("warnings = %v", res.Warnings)
+	}
+	if res.Engine != "forge/2.4.1" {
+		t.Errorf("Engine = %q", res.Engine)
+	}
+	if res.CacheStatus != CacheHit {
+		t.Errorf("CacheStatus = %q", res.CacheStatus)
+	}
+	if res.WorkerID != "worker-7" {
+		t.Errorf("WorkerID = %q", res.WorkerID)
+	}
+	if res.RenderDuration != 182500*time.Microsecond {
+		t.Errorf("RenderDuration = %v", res.RenderDuration)
+	}
+}
+
+func TestRenderResponseHeadersAbsent(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("%PDF"))
+	}))
+	defer srv.Close()
+
+	res, err := NewClient(srv.URL).RenderHTML("<p>x</p>").SendWithWarnings(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Engine != "" || res.CacheStatus != "" || res.WorkerID != "" || res.Locale != "" || res.RenderDuration != 0 {
+		t.Errorf("unexpected metadata: %+v", res)
+	}
+}
+
+func TestDocumentLocale(t *testing.T) {
+	var lang string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if lang != "" {
+			w.Header().Set("Content-Language", lang)
+		}
+		w.Write([]byte("%PDF"))
+	}))
+	defer srv.Close()
+	c := NewClient(srv.URL)
+
+	res, err := c.RenderHTML("<p>x</p>").DocumentLocale("de-CH").SendWithWarnings(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Locale != "de-CH" {
+		t.Errorf("Locale = %q, want the requested tag", res.Locale)
+	}
+
+	lang = "de"
+	res, err = c.RenderHTML("<p>x</p>").DocumentLocale("de-CH").SendWithWarnings(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Locale != "de" {
+		t.Errorf("Locale = %q, want the server's Content-Language", res.Locale)
+	}
+}
+
+func TestErrorLocale(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept-Language") != "de" {
+			t.Errorf("Accept-Language = %q", r.Header.Get("Accept-Language"))
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":"Unbekanntes Papierformat: leter","code":"invalid_paper"}`))
+	}))
+	defer srv.Close()
+
+	_, err := NewClient(srv.URL, WithErrorLocale("de")).RenderHTML("<p>x</p>").Paper("leter").Send(context.Background())
+	se, ok := err.(*ServerError)
+	if !ok {
+		t.Fatalf("err = %T, want *ServerError", err)
+	}
+	if se.Message != "Unbekanntes Papierformat: leter" {
+		t.Errorf("Message = %q", se.Message)
+	}
+	if se.Code != "invalid_paper" {
+		t.Errorf("Code = %q", se.Code)
+	}
+}
+
+func TestSendRaw(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Custom", "yes")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		w.Write([]byte(`{"error":"bad css"}`))
+	}))
+	defer srv.Close()
+
+	resp, err := NewClient(srv.URL).RenderHTML("<p>x</p>").SendRaw(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("status = %d", resp.StatusCode)
+	}
+	if resp.Header.Get("X-Custom") != "yes" {
+		t.Errorf("X-Custom = %q", resp.Header.Get("X-Custom"))
+	}
+	body, _ := io.ReadAll(resp.Body)
+	if string(body) != `{"error":"bad css"}` {
+		t.Errorf("body = %q", body)
+	}
+}
+
+func TestFormatAutoWithAccept(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var p map[string]any
+		json.NewDecoder(r.Body).Decode(&p)
+		if _, ok := p["format"]; ok {
+			t.Errorf("format = %v, want omitted", p["format"])
+		}
+		if r.Header.Get("Accept") != "image/png" {
+			t.Errorf("Accept = %q", r.Header.Get("Accept"))
+		}
+		w.Header().Set("Content-Type", "image/png")
+		w.Write([]byte("PNG"))
+	}))
+	defer srv.Close()
+
+	req := NewClient(srv.URL).RenderHTML("<p>x</p>").Format(FormatAuto)
+	if _, err := req.Accept("image/png").Send(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+type upperCodec struct{}
+
+func (upperCodec) ContentType() string { return "application/x-test" }
+func (upperCodec) Marshal(v any) ([]byte, error) {
+	data, err := json.Marshal(v)
+	return []byte(strings.ToUpper(string(data))), err
+}
+
+func TestWithCodec(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ct := r.Header.Get("Content-Type"); ct != "application/x-test" {
+			t.Errorf("Content-Type = %q", ct)
+		}
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"HTML":"HI","FORMAT":"PDF"}` {
+			t.Errorf("body = %s", body)
+		}
+		w.Write([]byte("%PDF"))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, WithCodec(upperCodec{}))
+	if _, err := c.RenderHTML("hi").Send(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSendPages(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var p map[string]any
+		json.NewDecoder(r.Body).Decode(&p)
+		if p["split_pages"] != true || p["flow"] != "paginate" {
+			t.Errorf("payload = %v", p)
+		}
+		mw := multipart.NewWriter(w)
+		w.Header().Set("Content-Type", "multipart/mixed; boundary="+mw.Boundary())
+		for _, n := range []string{"1", "2"} {
+			part, _ := mw.CreatePart(textproto.MIMEHeader{"Content-Type": {"image/png"}, "X-Forge-Page": {n}})
+			part.Write([]byte("png" + n))
+		}
+		mw.Close()
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+	pages, err := c.RenderHTML("x").Format(FormatPNG).SendPages(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pages) != 2 || pages[1].Number != 2 || string(pages[1].Data) != "png2" || pages[1].ContentType != "image/png" {
+		t.Errorf("pages = %+v", pages)
+	}
+
+	for _, r := range []*RenderRequest{
+		c.RenderHTML("x"),
+		c.RenderHTML("x").Format(FormatPNG).Flow(FlowContinuous),
+	} {
+		if _, err := r.SendPages(context.Background()); err == nil {
+			t.Error("SendPages succeeded, want *ValidationError")
+		}
+	}
+}
+
+func TestMultipartRequest(t *testing.T) {
+	type part struct{ name, filename, contentType, data string }
+	var got []part
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = nil
+		mr, err := r.MultipartReader()
+		if err != nil {
+			if r.Header.Get("Content-Type") != "application/json" {
+				t.Errorf("Content-Type = %q", r.Header.Get("Content-Type"))
+			}
+			w.Write([]byte("%PDF"))
+			return
+		}
+		for {
+			p, err := mr.NextPart()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, params, _ := mime.ParseMediaType(p.Header.Get("Content-Disposition"))
+			data, _ := io.ReadAll(p)
+			got = append(got, part{params["name"], params["filename"], p.Header.Get("Content-Type"), string(data)})
+		}
+		w.Write([]byte("%PDF"))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, WithMultipartThreshold(4))
+	if _, err := c.RenderHTML("x").Asset("a.css", []byte("p{}"), "text/css").Send(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if got != nil {
+		t.Fatalf("assets below the threshold sent as multipart: %v", got)
+	}
+
+	_, err := c.RenderHTML("x").
+		Asset("a.css", []byte("p{}"), "text/css").
+		Asset("img/b.png", []byte("\x89PNG"), "image/png").
+		Send(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("parts = %v", got)
+	}
+	if got[0].name != "payload" || got[0].contentType != "application/json" || strings.Contains(got[0].data, "assets") {
+		t.Errorf("payload part = %+v", got[0])
+	}
+	if want := (part{"assets", "img/b.png", "image/png", "\x89PNG"}); got[2] != want {
+		t.Errorf("asset part = %+v, want %+v", got[2], want)
+	}
+
+	// Embedded files and the watermark image count toward the threshold
+	// and travel raw.
+	_, err = c.RenderHTML("x").
+		PdfAttach("data.xml", base64.StdEncoding.EncodeToString([]byte("<x/>")), func(ef *EmbeddedFile) { ef.MimeType = "application/xml" }).
+		PdfWatermarkImage(base64.StdEncoding.EncodeToString([]byte("\x89PNG\r\n\x1a\n"))).
+		Send(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("parts = %v", got)
+	}
+	if strings.Contains(got[0].data, "image_data") || !strings.Contains(got[0].data, `"path":"data.xml","data":""`) {
+		t.Errorf("payload part = %s", got[0].data)
+	}
+	if want := (part{"embedded_files", "data.xml", "application/xml", "<x/>"}); got[1] != want {
+		t.Errorf("embedded file part = %+v, want %+v", got[1], want)
+	}
+	if want := (part{"watermark_image", "watermark", "image/png", "\x89PNG\r\n\x1a\n"}); got[2] != want {
+		t.Errorf("watermark part = %+v, want %+v", got[2], want)
+	}
+}
+
+func TestDeadlinePropagation(t *testing.T) {
+	var header string
+	var timeout any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Get("X-Forge-Timeout")
+		var p map[string]any
+		json.NewDecoder(r.Body).Decode(&p)
+		timeout = p["timeout"]
+		w.Write([]byte("%PDF"))
+	}))
+	defer srv.Close()
+	c := NewClient(srv.URL, WithDeadlineMargin(2*time.Second))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if _, err := c.RenderHTML("x").Send(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if ms, _ := strconv.Atoi(header); ms <= 7000 || ms > 8000 {
+		t.Errorf("X-Forge-Timeout = %q, want about 8000", header)
+	}
+	if timeout != 7.0 {
+		t.Errorf("timeout = %v, want 7", timeout)
+	}
+
+	// A shorter explicit timeout is kept.
+	if _, err := c.RenderHTML("x").Timeout(3).Send(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if timeout != 3.0 {
+		t.Errorf("timeout = %v, want 3", timeout)
+	}
+
+	// Without a deadline nothing is derived.
+	if _, err := c.RenderHTML("x").Send(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if header != "" || timeout != nil {
+		t.Errorf("X-Forge-Timeout = %q, timeout = %v; want neither", header, timeout)
+	}
+
+	short, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if _, err := c.RenderHTML("x").Send(short); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestResultTransform(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("%PDF"))
+	}))
+	defer srv.Close()
+
+	var order []string
+	stamp := func(ctx context.Context, res *RenderResponse) error {
+		order = append(order, "stamp")
+		res.Data = append(res.Data, " id=42"...)
+		return nil
+	}
+	scan := func(ctx context.Context, res *RenderResponse) error {
+		order = append(order, "scan")
+		if strings.Contains(string(res.Data), "virus") {
+			return errors.New("infected")
+		}
+		return nil
+	}
+	c := NewClient(srv.URL, WithResultTransform(stamp), WithResultTransform(scan))
+
+	data, err := c.RenderHTML("x").Send(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "%PDF id=42" || strings.Join(order, ",") != "stamp,scan" {
+		t.Errorf("data = %q, order = %v", data, order)
+	}
+
+	fail := NewClient(srv.URL, WithResultTransform(func(context.Context, *RenderResponse) error {
+		return errors.New("infected")
+	}))
+	if _, err := fail.RenderHTML("x").Send(context.Background()); err == nil || !strings.Contains(err.Error(), "infected") {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestUnexpectedContentType(t *testing.T) {
+	var contentType, body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+		w.Write([]byte(body))
+	}))
+	defer srv.Close()
+	c := NewClient(srv.URL)
+
+	contentType, body = "text/html; charset=utf-8", "<html><body>Please sign in</body></html>"
+	_, err := c.RenderHTML("x").Send(context.Background())
+	var ce *UnexpectedContentTypeError
+	if !errors.As(err, &ce) {
+		t.Fatalf("err = %v, want *UnexpectedContentTypeError", err)
+	}
+	if ce.Format != FormatPDF || ce.ContentType != contentType || string(ce.Head) != body {
+		t.Errorf("err = %+v", ce)
+	}
+
+	for _, tc := range []struct {
+		format            OutputFormat
+		contentType, body string
+	}{
+		{FormatPNG, "image/png", "\x89PNG"},
+		{FormatJPEG, "application/octet-stream", "data"},
+		{FormatPDF, "text/plain", "%PDF-1.7"},
+		{FormatAuto, "image/webp", "RIFF"},
+		{FormatHTML, "text/html; charset=utf-8", "<p>"},
+	} {
+		contentType, body = tc.contentType, tc.body
+		if _, err := c.RenderHTML("x").Format(tc.format).Send(context.Background()); err != nil {
+			t.Errorf("%s as %s: %v", tc.format, tc.contentType, err)
+		}
+	}
+}
+
+func TestClone(t *testing.T) {
+	c := NewClient("http://localhost")
+	base := c.RenderURL("https://example.com").PdfTitle("Base").PageHeader("X-Tenant", "a")
+	clone := base.Clone().PdfTitle("Clone").PageHeader("X-Tenant", "b")
+
+	if got := *base.Payload().Pdf.Title; got != "Base" {
+		t.Errorf("base title = %q", got)
+	}
+	if got := base.Payload().PageHeaders["X-Tenant"]; got != "a" {
+		t.Errorf("base header = %q", got)
+	}
+	if got := *clone.Payload().Pdf.Title; got != "Clone" {
+		t.Errorf("clone title = %q", got)
+	}
+
+	p := base.Payload()
+	p.Pdf.Title = nil
+	p.PageHeaders["X-Tenant"] = "c"
+	if base.Payload().Pdf.Title == nil || base.Payload().PageHeaders["X-Tenant"] != "a" {
+		t.Error("modifying Payload's result changed the request")
+	}
+}
+
+func TestFrozen(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		var p map[string]any
+		json.NewDecoder(r.Body).Decode(&p)
+		if p["html"] != "<p>x</p>" || p["pdf"] != nil {
+			t.Errorf("payload = %v", p)
+		}
+		w.Write([]byte("%PDF-1.7"))
+	}))
+	defer srv.Close()
+
+	req := NewClient(srv.URL).RenderHTML("<p>x</p>")
+	frozen, err := req.Frozen()
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.PdfTitle("Changed")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := frozen.Send(context.Background()); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	if calls.Load() != 8 {
+		t.Errorf("calls = %d", calls.Load())
+	}
+
+	if _, err := NewClient(srv.URL).RenderHTML("x").DocumentLocale("not a tag!").Frozen(); err == nil {
+		t.Error("invalid request frozen")
+	}
+}
+
+func TestVia(t *testing.T) {
+	var defaultCalls int
+	def := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defaultCalls++
+		w.Write([]byte("%PDF-1.7"))
+	}))
+	defer def.Close()
+	gpu := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/render" || r.Header.Get("Accept-Language") != "de" {
+			t.Errorf("request = %s, Accept-Language = %q", r.URL.Path, r.Header.Get("Accept-Language"))
+		}
+		w.Write([]byte("%PDF-1.7"))
+	}))
+	defer gpu.Close()
+
+	c := NewClient(def.URL, WithErrorLocale("de"))
+	if _, err := c.RenderHTML("x").Via(gpu.URL + "/").Send(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if defaultCalls != 0 {
+		t.Errorf("default server called %d times", defaultCalls)
+	}
+	if c.Metrics().Requests != 1 {
+		t.Errorf("metrics requests = %d", c.Metrics().Requests)
+	}
+
+	for _, u := range []string{"", "gpu-node:3000", "ftp://gpu-node"} {
+		err := c.RenderHTML("x").Via(u).validate()
+		if ve, ok := err.(*ValidationError); !ok || ve.Field != "via" {
+			t.Errorf("Via(%q): err = %v", u, err)
+		}
+	}
+}
+
+func TestAPIVersion(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if v := r.Header.Get("X-Forge-API-Version"); v != "2024-11" {
+			t.Errorf("X-Forge-API-Version = %q", v)
+		}
+		w.Write([]byte("%PDF-1.7"))
+	}))
+	defer srv.Close()
+
+	if _, err := NewClient(srv.URL, WithAPIVersion("2024-11")).RenderHTML("x").Send(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, v := range []string{"2024-13", "2024-1", "latest", "2999-01"} {
+		err := NewClient(srv.URL, WithAPIVersion(v)).RenderHTML("x").validate()
+		if ve, ok := err.(*ValidationError); !ok || ve.Field != "api_version" {
+			t.Errorf("WithAPIVersion(%q): err = %v", v, err)
+		}
+	}
+	// Non-render APIs never send a malformed header.
+	c := NewClient(srv.URL, WithAPIVersion("latest"))
+	if _, err := c.Fonts().List(context.Background()); !errors.As(err, new(*ValidationError)) {
+		t.Errorf("Fonts().List err = %v, want *ValidationError", err)
+	}
+	if _, err := c.Pdf().Optimize(context.Background(), []byte("%PDF-1.7"), OptimizeOptions{}); !errors.As(err, new(*ValidationError)) {
+		t.Errorf("Pdf().Optimize err = %v, want *ValidationError", err)
+	}
+}
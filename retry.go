@@ -0,0 +1,125 @@
+package forge
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how failed requests are retried.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first.
+	// Values below 2 disable retries.
+	MaxAttempts int
+	// Backoff is the delay before the first retry, doubled for each
+	// subsequent one (default 500ms).
+	Backoff time.Duration
+	// MaxBackoff caps the delay between attempts, including delays requested
+	// by a Retry-After header (default 10s).
+	MaxBackoff time.Duration
+	// RetryOn lists the response status codes that are retried
+	// (default 429, 502, 503, 504). Connection errors are always retried.
+	RetryOn []int
+}
+
+// WithRetry retries connection errors and transient server errors.
+func WithRetry(p RetryPolicy) Option {
+	return func(c *Client) {
+		if p.Backoff <= 0 {
+			p.Backoff = 500 * time.Millisecond
+		}
+		if p.MaxBackoff <= 0 {
+			p.MaxBackoff = 10 * time.Second
+		}
+		if p.RetryOn == nil {
+			p.RetryOn = []int{
+				http.StatusTooManyRequests,
+				http.StatusBadGateway,
+				http.StatusServiceUnavailable,
+				http.StatusGatewayTimeout,
+			}
+		}
+		c.retry = &p
+	}
+}
+
+// Attempt describes one HTTP attempt of a request.
+type Attempt struct {
+	// StatusCode is the response status, or 0 if no response arrived.
+	StatusCode int
+	// Err is the transport error, if the attempt failed before a response.
+	Err error
+	// Duration is the time until response headers (or the error) arrived.
+	Duration time.Duration
+}
+
+// exchange records the HTTP attempts behind one logical request.
+type exchange struct {
+	attempts []Attempt
+	start    time.Time
+}
+
+func (x *exchange) elapsed() time.Duration {
+	return time.Since(x.start)
+}
+
+// shouldRetry reports whether attempt n (1-based) may be followed by another.
+func (p *RetryPolicy) shouldRetry(n int, resp *http.Response, err error) bool {
+	if p == nil || n >= p.MaxAttempts {
+		return false
+	}
+	if err != nil {
+		return true
+	}
+	for _, code := range p.RetryOn {
+		if resp.StatusCode == code {
+			return true
+		}
+	}
+	return false
+}
+
+// delay returns the wait before attempt n+1, honoring Retry-After in
+// either its delay-seconds or HTTP-date form. It never exceeds MaxBackoff.
+func (p *RetryPolicy) delay(n int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if h := resp.Header.Get("Retry-After"); h != "" {
+			if secs, err := strconv.ParseInt(h, 10, 64); err == nil && secs >= 0 {
+				if secs > int64(p.MaxBackoff/time.Second) {
+					return p.MaxBackoff
+				}
+				return time.Duration(secs) * time.Second
+			}
+			if t, err := http.ParseTime(h); err == nil {
+				return min(max(time.Until(t), 0), p.MaxBackoff)
+			}
+		}
+	}
+	// Double the backoff per attempt, stopping at MaxBackoff before the
+	// shift can overflow.
+	d := p.Backoff
+	for i := 1; i < n && d < p.MaxBackoff; i++ {
+		if d > p.MaxBackoff/2 {
+			d = p.MaxBackoff
+			break
+		}
+		d *= 2
+	}
+	if d < 0 || d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	return d
+}
+
+// sleep waits for d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,135 @@
+package forge
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryTransientErrors(t *testing.T) {
+	var n atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if len(body) == 0 {
+			t.Error("retried request has empty body")
+		}
+		switch n.Add(1) {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 2:
+			w.WriteHeader(http.StatusBadGateway)
+		default:
+			w.Write([]byte("%PDF"))
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, WithRetry(RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond}))
+	res, err := c.RenderHTML("<p>x</p>").SendWithWarnings(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(res.Data) != "%PDF" {
+		t.Errorf("data = %q", res.Data)
+	}
+	if len(res.Attempts) != 3 {
+		t.Fatalf("attempts = %d, want 3", len(res.Attempts))
+	}
+	want := []int{503, 502, 200}
+	for i, a := range res.Attempts {
+		if a.StatusCode != want[i] {
+			t.Errorf("attempt %d status = %d, want %d", i, a.StatusCode, want[i])
+		}
+	}
+	if res.Elapsed <= 0 {
+		t.Errorf("Elapsed = %v", res.Elapsed)
+	}
+}
+
+func TestRetryExhausted(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, WithRetry(RetryPolicy{MaxAttempts: 2, Backoff: time.Millisecond}))
+	_, err := c.RenderHTML("<p>x</p>").Send(context.Background())
+	var se *ServerError
+	if !errors.As(err, &se) {
+		t.Fatalf("err = %v, want *ServerError", err)
+	}
+	if se.StatusCode != 429 || len(se.Attempts) != 2 {
+		t.Errorf("status = %d, attempts = %d", se.StatusCode, len(se.Attempts))
+	}
+}
+
+func TestNoRetryOnClientError(t *testing.T) {
+	var n atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n.Add(1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, WithRetry(RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond}))
+	c.RenderHTML("<p>x</p>").Send(context.Background())
+	if n.Load() != 1 {
+		t.Errorf("requests = %d, want 1", n.Load())
+	}
+}
+
+func TestRetryConnectionError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := srv.URL
+	srv.Close()
+
+	c := NewClient(url, WithRetry(RetryPolicy{MaxAttempts: 2, Backoff: time.Millisecond}))
+	_, err := c.RenderHTML("<p>x</p>").Send(context.Background())
+	var ce *ConnectionError
+	if !errors.As(err, &ce) {
+		t.Fatalf("err = %v, want *ConnectionError", err)
+	}
+	if len(ce.Attempts) != 2 || ce.Attempts[0].Err == nil || ce.Attempts[0].StatusCode != 0 {
+		t.Errorf("attempts = %+v", ce.Attempts)
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	p := &RetryPolicy{Backoff: 500 * time.Millisecond, MaxBackoff: 10 * time.Second}
+	header := func(v string) *http.Response {
+		return &http.Response{Header: http.Header{"Retry-After": {v}}}
+	}
+	for _, tt := range []struct {
+		n    int
+		resp *http.Response
+		want time.Duration
+	}{
+		{1, nil, 500 * time.Millisecond},
+		{3, nil, 2 * time.Second},
+		{6, nil, 10 * time.Second},
+		// Large attempt numbers must not overflow the shift to zero.
+		{65, nil, 10 * time.Second},
+		{1000, nil, 10 * time.Second},
+		{1, header("3"), 3 * time.Second},
+		{1, header("99999999999999"), 10 * time.Second},
+		{1, header(time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat)), 0},
+		{1, header(time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)), 10 * time.Second},
+		{1, header("soon"), 500 * time.Millisecond},
+	} {
+		if got := p.delay(tt.n, tt.resp); got != tt.want {
+			t.Errorf("delay(%d, %v) = %v, want %v", tt.n, tt.resp, got, tt.want)
+		}
+	}
+
+	// An HTTP date a few seconds ahead is waited for.
+	d := p.delay(1, header(time.Now().Add(5*time.Second).UTC().Format(http.TimeFormat)))
+	if d < 3*time.Second || d > 5*time.Second {
+		t.Errorf("delay for HTTP date = %v, want about 4s", d)
+	}
+}
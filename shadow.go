@@ -0,0 +1,189 @@
+package forge
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Renderer renders payloads. *Client and *Shadow implement it.
+type Renderer interface {
+	Render(ctx context.Context, p *RenderPayload) (*RenderResponse, error)
+}
+
+// Render sends p as a render request, like FromPayload(p).SendWithWarnings.
+func (c *Client) Render(ctx context.Context, p *RenderPayload) (*RenderResponse, error) {
+	return c.FromPayload(p).SendWithWarnings(ctx)
+}
+
+// Sampler decides which requests a Shadow also sends to its candidate.
+type Sampler func(p *RenderPayload) bool
+
+// SampleRate returns a Sampler that selects each request with probability
+// rate, from 0 (none) to 1 (all).
+func SampleRate(rate float64) Sampler {
+	return func(*RenderPayload) bool {
+		return rand.Float64() < rate
+	}
+}
+
+// ShadowReport describes a request on which the candidate diverged from
+// the primary.
+type ShadowReport struct {
+	Payload   *RenderPayload
+	Primary   *RenderResponse
+	Candidate *RenderResponse
+	// CandidateErr is the candidate's error, if it failed where the
+	// primary succeeded.
+	CandidateErr error
+	// Differences describes each divergence, e.g. "text: page 2 differs".
+	Differences []string
+}
+
+// ShadowReporter receives divergences found by a Shadow. It is called from
+// background goroutines and must be safe for concurrent use.
+type ShadowReporter func(ShadowReport)
+
+// Shadow is a Renderer that serves every request from a primary server
+// and mirrors a sample of them to a candidate server in the background,
+// reporting any difference in output. Create one with ShadowRenderer.
+type Shadow struct {
+	primary, candidate Renderer
+	sample             Sampler
+	report             ShadowReporter
+
+	// CandidateTimeout bounds each mirrored render (default 2 minutes).
+	// Mirrored renders do not inherit the caller's cancellation.
+	CandidateTimeout time.Duration
+
+	wg sync.WaitGroup
+}
+
+// ShadowRenderer returns a Shadow that validates a candidate Forge server,
+// typically a new version, against production traffic. Callers only ever
+// see the primary's results and latency.
+//
+// Output is compared by text layout when both responses include one (see
+// IncludeTextLayout), since byte-identical documents across server
+// versions are rare; otherwise the raw bytes are compared.
+//
+// A nil sample mirrors no requests, and a nil report discards
+// divergences.
+func ShadowRenderer(primary, candidate Renderer, sample Sampler, report ShadowReporter) *Shadow {
+	if sample == nil {
+		sample = func(*RenderPayload) bool { return false }
+	}
+	if report == nil {
+		report = func(ShadowReport) {}
+	}
+	return &Shadow{
+		primary:          primary,
+		candidate:        candidate,
+		sample:           sample,
+		report:           report,
+		CandidateTimeout: 2 * time.Minute,
+	}
+}
+
+// Render renders p on the primary and, if sampled and successful, mirrors
+// it to the candidate.
+func (s *Shadow) Render(ctx context.Context, p *RenderPayload) (*RenderResponse, error) {
+	res, err := s.primary.Render(ctx, p)
+	if err != nil || !s.sample(p) {
+		return res, err
+	}
+
+	// The caller owns p and res once Render returns, so the mirrored
+	// render works on copies.
+	p, primary := clonePayload(p), cloneResponse(res)
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		cctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), s.CandidateTimeout)
+		defer cancel()
+		cand, err := s.candidate.Render(cctx, p)
+		rep := ShadowReport{Payload: p, Primary: primary, Candidate: cand, CandidateErr: err}
+		if err != nil {
+			rep.Differences = []string{fmt.Sprintf("candidate failed: %v", err)}
+		} else {
+			rep.Differences = compareRenders(primary, cand)
+		}
+		if len(rep.Differences) > 0 {
+			s.report(rep)
+		}
+	}()
+	return res, nil
+}
+
+// Wait blocks until all mirrored renders have finished, e.g. before
+// shutdown.
+func (s *Shadow) Wait() {
+	s.wg.Wait()
+}
+
+// cloneResponse returns a deep copy of res, including its data.
+func cloneResponse(res *RenderResponse) *RenderResponse {
+	c := deepCopy(reflect.ValueOf(res)).Interface().(*RenderResponse)
+	c.Data = bytes.Clone(res.Data)
+	return c
+}
+
+// compareRenders describes the differences between two successful renders.
+func compareRenders(a, b *RenderResponse) []string {
+	var diffs []string
+	if a.TextLayout != nil && b.TextLayout != nil {
+		da, _ := a.TextDump()
+		db, _ := b.TextDump()
+		diffs = append(diffs, diffTextDumps(da, db)...)
+	} else if !bytes.Equal(a.Data, b.Data) {
+		diffs = append(diffs, fmt.Sprintf("data: %d bytes vs %d bytes", len(a.Data), len(b.Data)))
+	}
+	if !slices.Equal(a.Warnings, b.Warnings) {
+		diffs = append(diffs, fmt.Sprintf("warnings: %q vs %q", a.Warnings, b.Warnings))
+	}
+	return diffs
+}
+
+// diffTextDumps reports the pages on which two TextDump outputs differ,
+// with the first differing line of each.
+func diffTextDumps(a, b string) []string {
+	pa, pb := splitDumpPages(a), splitDumpPages(b)
+	if len(pa) != len(pb) {
+		return []string{fmt.Sprintf("text: %d pages vs %d pages", len(pa), len(pb))}
+	}
+	var diffs []string
+	for i := range pa {
+		la, lb := strings.Split(pa[i], "\n"), strings.Split(pb[i], "\n")
+		for j := 0; j < max(len(la), len(lb)); j++ {
+			var x, y string
+			if j < len(la) {
+				x = la[j]
+			}
+			if j < len(lb) {
+				y = lb[j]
+			}
+			if x != y {
+				diffs = append(diffs, fmt.Sprintf("text: page %d differs: %q vs %q", i+1, x, y))
+				break
+			}
+		}
+	}
+	return diffs
+}
+
+// splitDumpPages splits a TextDump into per-page sections.
+func splitDumpPages(dump string) []string {
+	var pages []string
+	for _, p := range strings.Split(dump, "=== page ") {
+		if p != "" {
+			pages = append(pages, p)
+		}
+	}
+	return pages
+}
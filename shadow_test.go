@@ -0,0 +1,134 @@
+package forge
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type rendererFunc func(ctx context.Context, p *RenderPayload) (*RenderResponse, error)
+
+func (f rendererFunc) Render(ctx context.Context, p *RenderPayload) (*RenderResponse, error) {
+	return f(ctx, p)
+}
+
+func TestShadowRenderer(t *testing.T) {
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("%PDF-1.7 v1"))
+	}))
+	defer primary.Close()
+	candidate := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("X-Forge-Warning", "new warning")
+		w.Write([]byte("%PDF-1.7 v2!"))
+	}))
+	defer candidate.Close()
+
+	var mu sync.Mutex
+	var reports []ShadowReport
+	s := ShadowRenderer(NewClient(primary.URL), NewClient(candidate.URL), SampleRate(1), func(r ShadowReport) {
+		mu.Lock()
+		reports = append(reports, r)
+		mu.Unlock()
+	})
+
+	res, err := s.Render(context.Background(), NewClient("").RenderHTML("x").Payload())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(res.Data) != "%PDF-1.7 v1" {
+		t.Errorf("Data = %q, want the primary's", res.Data)
+	}
+	s.Wait()
+	if len(reports) != 1 {
+		t.Fatalf("reports = %d, want 1", len(reports))
+	}
+	got := strings.Join(reports[0].Differences, "; ")
+	if !strings.Contains(got, "data: 11 bytes vs 12 bytes") || !strings.Contains(got, "warnings") {
+		t.Errorf("Differences = %v", reports[0].Differences)
+	}
+
+	// Unsampled requests are not mirrored.
+	s = ShadowRenderer(NewClient(primary.URL), NewClient(candidate.URL), SampleRate(0), func(ShadowReport) {
+		t.Error("unexpected report")
+	})
+	s.Render(context.Background(), NewClient("").RenderHTML("x").Payload())
+	s.Wait()
+}
+
+func TestShadowRendererTextLayout(t *testing.T) {
+	layout := func(text string) *TextLayout {
+		return &TextLayout{Pages: []TextPage{
+			{Number: 1, Width: 595, Height: 842, Blocks: []TextBlock{{X: 72, Y: 72, Width: 100, Height: 12, Font: "Helvetica", FontSize: 12, Text: "Invoice"}}},
+			{Number: 2, Width: 595, Height: 842, Blocks: []TextBlock{{X: 72, Y: 72, Width: 100, Height: 12, Font: "Helvetica", FontSize: 12, Text: text}}},
+		}}
+	}
+	primary := rendererFunc(func(context.Context, *RenderPayload) (*RenderResponse, error) {
+		return &RenderResponse{Data: []byte("a"), TextLayout: layout("Total 10")}, nil
+	})
+	same := rendererFunc(func(context.Context, *RenderPayload) (*RenderResponse, error) {
+		return &RenderResponse{Data: []byte("b"), TextLayout: layout("Total 10")}, nil
+	})
+	moved := rendererFunc(func(context.Context, *RenderPayload) (*RenderResponse, error) {
+		return &RenderResponse{Data: []byte("c"), TextLayout: layout("Total 1O")}, nil
+	})
+	failing := rendererFunc(func(context.Context, *RenderPayload) (*RenderResponse, error) {
+		return nil, errors.New("boom")
+	})
+
+	for _, tc := range []struct {
+		name      string
+		candidate Renderer
+		want      string
+	}{
+		{"same text, different bytes", same, ""},
+		{"different text", moved, "text: page 2 differs"},
+		{"candidate error", failing, "candidate failed: boom"},
+	} {
+		var got []string
+		s := ShadowRenderer(primary, tc.candidate, SampleRate(1), func(r ShadowReport) { got = r.Differences })
+		s.Render(context.Background(), &RenderPayload{})
+		s.Wait()
+		if joined := strings.Join(got, "; "); (tc.want == "") != (joined == "") || !strings.Contains(joined, tc.want) {
+			t.Errorf("%s: Differences = %v, want %q", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestShadowRendererCopies(t *testing.T) {
+	release := make(chan struct{})
+	primary := rendererFunc(func(ctx context.Context, p *RenderPayload) (*RenderResponse, error) {
+		return &RenderResponse{Data: []byte("same")}, nil
+	})
+	candidate := rendererFunc(func(ctx context.Context, p *RenderPayload) (*RenderResponse, error) {
+		<-release
+		if *p.HTML != "x" {
+			t.Errorf("candidate saw HTML %q", *p.HTML)
+		}
+		return &RenderResponse{Data: []byte("same")}, nil
+	})
+	s := ShadowRenderer(primary, candidate, SampleRate(1), func(r ShadowReport) {
+		t.Errorf("unexpected report: %v", r.Differences)
+	})
+
+	p := NewClient("").RenderHTML("x").Payload()
+	res, err := s.Render(context.Background(), p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The caller reuses the payload and response while the mirror runs.
+	*p.HTML = "y"
+	copy(res.Data, "diff")
+	close(release)
+	s.Wait()
+
+	// Nil sample and report are safe.
+	s = ShadowRenderer(primary, candidate, nil, nil)
+	if _, err := s.Render(context.Background(), p); err != nil {
+		t.Fatal(err)
+	}
+	s.Wait()
+}
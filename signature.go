@@ -0,0 +1,70 @@
+package forge
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// SignatureInfo describes one digital signature in a PDF, as checked by
+// VerifySignatures.
+type SignatureInfo struct {
+	SignerName string `json:"signer_name"`
+	Reason     string `json:"reason,omitempty"`
+	Location   string `json:"location,omitempty"`
+	// SignedAt is the signing time, from the timestamp token if present and
+	// otherwise as claimed by the signer.
+	SignedAt time.Time `json:"signed_at"`
+	// Timestamped reports whether an RFC 3161 timestamp vouches for SignedAt.
+	Timestamped bool `json:"timestamped,omitempty"`
+	// Chain is the signer's certificate chain, signer first.
+	Chain []CertificateInfo `json:"chain"`
+	// CoversDocument reports whether the signature covers the whole file,
+	// i.e. nothing was appended after signing.
+	CoversDocument bool `json:"covers_document"`
+	// Valid reports whether the signature is intact, covers the document,
+	// and chains to a certificate the server trusts.
+	Valid bool `json:"valid"`
+	// Problems explains why a signature is not valid.
+	Problems []string `json:"problems,omitempty"`
+}
+
+// CertificateInfo summarizes a certificate of a signature's chain.
+type CertificateInfo struct {
+	Subject   string    `json:"subject"`
+	Issuer    string    `json:"issuer"`
+	NotBefore time.Time `json:"not_before"`
+	NotAfter  time.Time `json:"not_after"`
+	// DER is the encoded certificate, base64-encoded in JSON.
+	DER []byte `json:"der"`
+}
+
+// Certificate parses the encoded certificate.
+func (ci CertificateInfo) Certificate() (*x509.Certificate, error) {
+	return x509.ParseCertificate(ci.DER)
+}
+
+// VerifySignatures checks the digital signatures of a PDF and returns one
+// SignatureInfo per signature, in signing order. A PDF without signatures
+// yields an empty list. Invalid signatures are reported through
+// SignatureInfo.Valid, not as an error.
+func (p *PdfClient) VerifySignatures(ctx context.Context, pdf []byte) ([]SignatureInfo, error) {
+	if err := checkPDF("pdf", pdf); err != nil {
+		return nil, err
+	}
+	data, err := p.c.postJSON(ctx, "/pdf/signatures/verify", struct {
+		PDF []byte `json:"pdf"`
+	}{pdf})
+	if err != nil {
+		return nil, err
+	}
+	var out struct {
+		Signatures []SignatureInfo `json:"signatures"`
+	}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, fmt.Errorf("forge: decode signatures: %w", err)
+	}
+	return out.Signatures, nil
+}
@@ -0,0 +1,219 @@
+package forge
+
+import (
+	"encoding/csv"
+	"html"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// TableTheme selects the styling of a rendered table.
+type TableTheme string
+
+const (
+	TableThemePlain   TableTheme = "plain"
+	TableThemeStriped TableTheme = "striped"
+	TableThemeGrid    TableTheme = "grid"
+)
+
+var tableThemeCSS = map[TableTheme]string{
+	TableThemePlain: `th{border-bottom:2px solid #333}`,
+	TableThemeStriped: `th{background:#333;color:#fff}
+tbody tr:nth-child(even){background:#f2f2f2}`,
+	TableThemeGrid: `th,td{border:1px solid #999}
+th{background:#e6e6e6}`,
+}
+
+// TableOptions controls how RenderTable and RenderCSV lay out a table.
+type TableOptions struct {
+	// Title is shown above the table and used as the document title.
+	Title string
+	// Theme is the table styling (default TableThemeStriped).
+	Theme TableTheme
+	// NoHeader renders the first row as data instead of a header.
+	NoHeader bool
+	// Columns sets per-column width, alignment, and number formatting,
+	// by column index. Columns beyond the slice use defaults.
+	Columns []TableColumn
+	// FontSize is the CSS font size of the table (default "10pt").
+	FontSize string
+}
+
+// TableColumn configures one table column.
+type TableColumn struct {
+	// Width is a CSS width, e.g. "30%" or "40mm".
+	Width string
+	// Align is "left", "center", or "right". Number columns default to right.
+	Align string
+	// Number formats cells that parse as numbers. Other cells are unchanged.
+	Number *NumberFormat
+}
+
+// NumberFormat formats numeric table cells.
+type NumberFormat struct {
+	// Decimals is the number of digits after the decimal point.
+	Decimals int
+	// Grouping inserts thousands separators ("1,234,567").
+	Grouping bool
+	// Prefix and Suffix surround the number, e.g. "$" or " %".
+	Prefix string
+	Suffix string
+}
+
+// Format formats s if it is a finite decimal number, such as "-1234.5" or
+// "1e6", and returns it unchanged otherwise.
+func (f NumberFormat) Format(s string) string {
+	t := strings.TrimSpace(s)
+	if !isDecimal(t) {
+		return s
+	}
+	v, err := strconv.ParseFloat(t, 64)
+	if err != nil {
+		return s
+	}
+	out := strconv.FormatFloat(v, 'f', f.Decimals, 64)
+	if f.Grouping {
+		out = groupThousands(out)
+	}
+	return f.Prefix + out + f.Suffix
+}
+
+// isDecimal reports whether s is a decimal number with an optional sign
+// and exponent. Unlike strconv.ParseFloat, it rejects "Inf", "NaN", and
+// hexadecimal floats.
+func isDecimal(s string) bool {
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "-"), "+")
+	mant, exp, hasExp := strings.Cut(strings.ToLower(s), "e")
+	if hasExp {
+		exp = strings.TrimPrefix(strings.TrimPrefix(exp, "-"), "+")
+		if exp == "" || strings.Trim(exp, "0123456789") != "" {
+			return false
+		}
+	}
+	intPart, frac, _ := strings.Cut(mant, ".")
+	return intPart+frac != "" && strings.Trim(intPart+frac, "0123456789") == ""
+}
+
+func groupThousands(s string) string {
+	sign := ""
+	if s[0] == '-' || s[0] == '+' {
+		sign, s = s[:1], s[1:]
+	}
+	intPart, frac := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		intPart, frac = s[:i], s[i:]
+	}
+	var b strings.Builder
+	for i, c := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(c)
+	}
+	return sign + b.String() + frac
+}
+
+// RenderTable starts a render request for rows laid out as a styled HTML
+// table. The first row is the header unless opts.NoHeader is set.
+func (c *Client) RenderTable(rows [][]string, opts TableOptions) *RenderRequest {
+	r := c.RenderHTML(tableHTML(rows, opts))
+	if opts.Title != "" {
+		r.PdfTitle(opts.Title)
+	}
+	return r
+}
+
+// RenderCSV is RenderTable for CSV input. A malformed CSV is reported by
+// the request's Send methods.
+func (c *Client) RenderCSV(src io.Reader, opts TableOptions) *RenderRequest {
+	cr := csv.NewReader(src)
+	cr.FieldsPerRecord = -1
+	rows, err := cr.ReadAll()
+	if err != nil {
+		r := c.RenderHTML("")
+		r.fail("csv", "%v", err)
+		return r
+	}
+	return c.RenderTable(rows, opts)
+}
+
+func tableHTML(rows [][]string, opts TableOptions) string {
+	theme := opts.Theme
+	if _, ok := tableThemeCSS[theme]; !ok {
+		theme = TableThemeStriped
+	}
+	fontSize := opts.FontSize
+	if fontSize == "" {
+		fontSize = "10pt"
+	}
+	column := func(i int) TableColumn {
+		if i < len(opts.Columns) {
+			return opts.Columns[i]
+		}
+		return TableColumn{}
+	}
+
+	var b strings.Builder
+	b.WriteString("<!DOCTYPE html>\n<html><head><meta charset=\"utf-8\">")
+	if opts.Title != "" {
+		b.WriteString("<title>" + html.EscapeString(opts.Title) + "</title>")
+	}
+	b.WriteString("<style>\nbody{font-family:sans-serif}\n")
+	b.WriteString("table{border-collapse:collapse;width:100%;font-size:" + cssValue(fontSize) + "}\n")
+	b.WriteString("th,td{padding:4px 8px;text-align:left}\nthead{display:table-header-group}\ntr{break-inside:avoid}\n")
+	b.WriteString(tableThemeCSS[theme])
+	b.WriteString("\n</style></head><body>\n")
+	if opts.Title != "" {
+		b.WriteString("<h1>" + html.EscapeString(opts.Title) + "</h1>\n")
+	}
+	b.WriteString("<table>\n")
+
+	if len(opts.Columns) > 0 {
+		b.WriteString("<colgroup>")
+		for _, col := range opts.Columns {
+			if col.Width != "" {
+				b.WriteString(`<col style="width:` + html.EscapeString(cssValue(col.Width)) + `">`)
+			} else {
+				b.WriteString("<col>")
+			}
+		}
+		b.WriteString("</colgroup>\n")
+	}
+
+	body := rows
+	if !opts.NoHeader && len(rows) > 0 {
+		b.WriteString("<thead><tr>")
+		for i, cell := range rows[0] {
+			b.WriteString("<th" + alignAttr(column(i)) + ">" + html.EscapeString(cell) + "</th>")
+		}
+		b.WriteString("</tr></thead>\n")
+		body = rows[1:]
+	}
+
+	b.WriteString("<tbody>\n")
+	for _, row := range body {
+		b.WriteString("<tr>")
+		for i, cell := range row {
+			col := column(i)
+			if col.Number != nil {
+				cell = col.Number.Format(cell)
+			}
+			b.WriteString("<td" + alignAttr(col) + ">" + html.EscapeString(cell) + "</td>")
+		}
+		b.WriteString("</tr>\n")
+	}
+	b.WriteString("</tbody>\n</table>\n</body></html>\n")
+	return b.String()
+}
+
+func alignAttr(col TableColumn) string {
+	align := col.Align
+	if align == "" && col.Number != nil {
+		align = "right"
+	}
+	if align == "" {
+		return ""
+	}
+	return ` style="text-align:` + html.EscapeString(cssValue(align)) + `"`
+}
@@ -0,0 +1,94 @@
+package forge
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestNumberFormat(t *testing.T) {
+	tests := []struct {
+		f    NumberFormat
+		in   string
+		want string
+	}{
+		{NumberFormat{Decimals: 2, Grouping: true, Prefix: "$"}, "1234567.891", "$1,234,567.89"},
+		{NumberFormat{Grouping: true}, "-1234", "-1,234"},
+		{NumberFormat{Decimals: 1, Suffix: " %"}, "12.34", "12.3 %"},
+		{NumberFormat{Decimals: 2}, "n/a", "n/a"},
+		{NumberFormat{Grouping: true}, "+1234", "1,234"},
+		{NumberFormat{Grouping: true}, "1.5e6", "1,500,000"},
+		{NumberFormat{Grouping: true}, "Infinity", "Infinity"},
+		{NumberFormat{Grouping: true}, "-inf", "-inf"},
+		{NumberFormat{Grouping: true}, "nan", "nan"},
+		{NumberFormat{Grouping: true}, "0x1p4", "0x1p4"},
+		{NumberFormat{Grouping: true}, "1e400", "1e400"},
+		{NumberFormat{}, ".", "."},
+		{NumberFormat{}, "1e", "1e"},
+	}
+	for _, tt := range tests {
+		if got := tt.f.Format(tt.in); got != tt.want {
+			t.Errorf("Format(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestRenderTable(t *testing.T) {
+	c := NewClient("http://localhost:3000")
+	r := c.RenderTable([][]string{
+		{"Region", "Revenue"},
+		{"<North>", "1500000"},
+	}, TableOptions{
+		Title:   "Q3",
+		Theme:   TableThemeGrid,
+		Columns: []TableColumn{{Width: "60%"}, {Number: &NumberFormat{Grouping: true}}},
+	})
+	p := r.Payload()
+	h := *p.HTML
+	for _, want := range []string{
+		"<th>Region</th>",
+		"<td>&lt;North&gt;</td>",
+		`<td style="text-align:right">1,500,000</td>`,
+		`<col style="width:60%">`,
+		"border:1px solid #999",
+	} {
+		if !strings.Contains(h, want) {
+			t.Errorf("html missing %q", want)
+		}
+	}
+	if p.Pdf == nil || *p.Pdf.Title != "Q3" {
+		t.Error("pdf title not set")
+	}
+
+	// CSS values cannot break out of their declaration.
+	h = *c.RenderTable([][]string{{"a"}}, TableOptions{
+		FontSize: "10pt}body{display:none",
+		Columns:  []TableColumn{{Width: `1px;x:y" onclick="z`, Align: "left;color:red"}},
+	}).Payload().HTML
+	for _, bad := range []string{"}body{", "1px;", `" onclick`, "left;"} {
+		if strings.Contains(h, bad) {
+			t.Errorf("html contains %q:\n%s", bad, h)
+		}
+	}
+}
+
+func TestRenderCSV(t *testing.T) {
+	c := NewClient("http://localhost:3000")
+	r := c.RenderCSV(strings.NewReader("a,b\n1,2\n"), TableOptions{NoHeader: true})
+	if h := *r.Payload().HTML; strings.Contains(h, "<thead>") || !strings.Contains(h, "<td>a</td>") {
+		t.Errorf("html = %s", h)
+	}
+
+	_, err := c.RenderCSV(strings.NewReader("a,\"b\n"), TableOptions{}).Send(context.Background())
+	if ve, ok := err.(*ValidationError); !ok || ve.Field != "csv" {
+		t.Errorf("err = %v, want csv *ValidationError", err)
+	}
+}
+
+func TestGroupThousands(t *testing.T) {
+	for in, want := range map[string]string{"1234": "1,234", "-1234.5": "-1,234.5", "+1234567": "+1,234,567", "12": "12"} {
+		if got := groupThousands(in); got != want {
+			t.Errorf("groupThousands(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,204 @@
+package forge
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// TemplateChannel selects a stored template release channel.
+type TemplateChannel string
+
+const (
+	// ChannelStable renders the template's current stable version.
+	ChannelStable TemplateChannel = "stable"
+	// ChannelCanary renders the candidate version on the fraction of
+	// traffic the server's rollout allows, and stable otherwise.
+	ChannelCanary TemplateChannel = "canary"
+)
+
+// TemplateOptions selects a stored template and the data to render it with.
+type TemplateOptions struct {
+	ID      string           `json:"id,omitempty"`
+	Data    map[string]any   `json:"data,omitempty"`
+	Version *string          `json:"version,omitempty"`
+	Channel *TemplateChannel `json:"channel,omitempty"`
+}
+
+// RenderTemplate executes tmpl locally with data and starts a render
+// request for the resulting HTML. An execution error is reported by the
+// request's Send methods. For templates stored on the server, use
+// RenderTemplateID instead.
+func (c *Client) RenderTemplate(tmpl *template.Template, data any) *RenderRequest {
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		r := c.RenderHTML("")
+		r.fail("template", "%v", err)
+		return r
+	}
+	return c.RenderHTML(b.String())
+}
+
+// RenderTemplateID starts a render request for the template stored on
+// the server under id, rendered with data. See Client.Templates.
+func (c *Client) RenderTemplateID(id string, data map[string]any) *RenderRequest {
+	r := &RenderRequest{client: c, p: RenderPayload{Template: &TemplateOptions{ID: id, Data: data}}}
+	if _, err := templatePath(id); err != nil {
+		r.err = err
+	}
+	return r
+}
+
+// template returns the request's template options, creating them if needed.
+func (r *RenderRequest) template() *TemplateOptions {
+	if r.p.Template == nil {
+		r.p.Template = &TemplateOptions{}
+	}
+	return r.p.Template
+}
+
+// TemplateVersion pins the stored template to an exact version, e.g. "v14".
+// It cannot be combined with TemplateChannel.
+func (r *RenderRequest) TemplateVersion(v string) *RenderRequest {
+	if v == "" {
+		r.fail("template.version", "must not be empty")
+		return r
+	}
+	r.template().Version = &v
+	return r
+}
+
+// TemplateChannel renders the stored template from a release channel
+// instead of a pinned version. It cannot be combined with TemplateVersion.
+func (r *RenderRequest) TemplateChannel(ch TemplateChannel) *RenderRequest {
+	if ch != ChannelStable && ch != ChannelCanary {
+		r.fail("template.channel", "unknown channel %q", ch)
+		return r
+	}
+	r.template().Channel = &ch
+	return r
+}
+
+// validateTemplate checks that template pinning targets a stored template
+// and that a version and a channel are not both requested.
+func (r *RenderRequest) validateTemplate() error {
+	t := r.p.Template
+	if t == nil {
+		return nil
+	}
+	if t.ID == "" {
+		return &ValidationError{Field: "template.id", Message: "version and channel apply only to stored templates"}
+	}
+	if t.Version != nil && t.Channel != nil {
+		return &ValidationError{Field: "template", Message: "set either a version or a channel, not both"}
+	}
+	return nil
+}
+
+// TemplatesClient manages the templates stored on the server. Obtain one
+// with Client.Templates.
+type TemplatesClient struct {
+	c *Client
+}
+
+// Templates returns the client for the server's template registry API.
+func (c *Client) Templates() *TemplatesClient {
+	return &TemplatesClient{c: c}
+}
+
+// StoredTemplate is a template stored on the server.
+type StoredTemplate struct {
+	ID string `json:"id"`
+	// HTML is the template source. List omits it.
+	HTML string `json:"html,omitempty"`
+	// Version is assigned by the server on every Create and Update, e.g.
+	// "v14", for use with TemplateVersion.
+	Version   string    `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Create stores a new template under id. It fails if the id is taken.
+func (t *TemplatesClient) Create(ctx context.Context, id, html string) (*StoredTemplate, error) {
+	if _, err := templatePath(id); err != nil {
+		return nil, err
+	}
+	req, err := t.c.newJSONRequest(ctx, http.MethodPost, "/templates", struct {
+		ID   string `json:"id"`
+		HTML string `json:"html"`
+	}{id, html})
+	if err != nil {
+		return nil, err
+	}
+	data, err := t.c.callAdmin(req)
+	if err != nil {
+		return nil, err
+	}
+	return decodeTemplate(data)
+}
+
+// Update replaces the source of the template stored under id, creating a
+// new version. Earlier versions remain available to TemplateVersion.
+func (t *TemplatesClient) Update(ctx context.Context, id, html string) (*StoredTemplate, error) {
+	path, err := templatePath(id)
+	if err != nil {
+		return nil, err
+	}
+	data, err := t.c.sendJSON(ctx, http.MethodPut, path, struct {
+		HTML string `json:"html"`
+	}{html})
+	if err != nil {
+		return nil, err
+	}
+	return decodeTemplate(data)
+}
+
+// List returns the stored templates, at their latest versions.
+func (t *TemplatesClient) List(ctx context.Context) ([]StoredTemplate, error) {
+	req, err := t.c.newRequest(ctx, http.MethodGet, "/templates", nil, "")
+	if err != nil {
+		return nil, fmt.Errorf("forge: request error: %w", err)
+	}
+	data, err := t.c.call(req)
+	if err != nil {
+		return nil, err
+	}
+	var out struct {
+		Templates []StoredTemplate `json:"templates"`
+	}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, fmt.Errorf("forge: decode templates: %w", err)
+	}
+	return out.Templates, nil
+}
+
+// Delete removes the template stored under id, with all its versions.
+func (t *TemplatesClient) Delete(ctx context.Context, id string) error {
+	path, err := templatePath(id)
+	if err != nil {
+		return err
+	}
+	req, err := t.c.newRequest(ctx, http.MethodDelete, path, nil, "")
+	if err != nil {
+		return fmt.Errorf("forge: request error: %w", err)
+	}
+	_, err = t.c.callAdmin(req)
+	return err
+}
+
+func decodeTemplate(data []byte) (*StoredTemplate, error) {
+	var st StoredTemplate
+	if err := json.Unmarshal(data, &st); err != nil {
+		return nil, fmt.Errorf("forge: decode template: %w", err)
+	}
+	return &st, nil
+}
+
+// templatePath returns the API path of the template stored under id.
+func templatePath(id string) (string, error) {
+	return resourcePath("/templates", "template.id", "template id", id)
+}
@@ -0,0 +1,77 @@
+package forge
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestTemplatesClient(t *testing.T) {
+	var deleted string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/templates":
+			if body["id"] != "invoice" || body["html"] != "<h1>{{.number}}</h1>" {
+				t.Errorf("create body = %v", body)
+			}
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id":"invoice","html":"<h1>{{.number}}</h1>","version":"v1","created_at":"2026-01-02T03:04:05Z","updated_at":"2026-01-02T03:04:05Z"}`))
+		case r.Method == http.MethodPut && r.URL.Path == "/templates/invoice":
+			if body["html"] != "<h2>{{.number}}</h2>" {
+				t.Errorf("update body = %v", body)
+			}
+			w.Write([]byte(`{"id":"invoice","html":"<h2>{{.number}}</h2>","version":"v2"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/templates":
+			w.Write([]byte(`{"templates":[{"id":"invoice","version":"v2"}]}`))
+		case r.Method == http.MethodDelete:
+			deleted = r.URL.Path
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+	templates := NewClient(srv.URL).Templates()
+	ctx := context.Background()
+
+	st, err := templates.Create(ctx, "invoice", "<h1>{{.number}}</h1>")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st.Version != "v1" || st.CreatedAt.IsZero() {
+		t.Errorf("Create = %+v", st)
+	}
+	if st, err = templates.Update(ctx, "invoice", "<h2>{{.number}}</h2>"); err != nil || st.Version != "v2" {
+		t.Errorf("Update = %+v, %v", st, err)
+	}
+	list, err := templates.List(ctx)
+	if err != nil || len(list) != 1 || list[0].ID != "invoice" {
+		t.Errorf("List = %+v, %v", list, err)
+	}
+	if err := templates.Delete(ctx, "invoice"); err != nil || deleted != "/templates/invoice" {
+		t.Errorf("Delete: %q, %v", deleted, err)
+	}
+	if _, err := templates.Update(ctx, "../fonts", "x"); err == nil {
+		t.Error("Update accepted a path")
+	}
+}
+
+func TestRenderTemplateID(t *testing.T) {
+	c := NewClient("http://localhost:3000")
+	p := payloadMap(t, c.RenderTemplateID("invoice", map[string]any{"number": 42}).TemplateVersion("v2"))
+	want := map[string]any{"id": "invoice", "data": map[string]any{"number": float64(42)}, "version": "v2"}
+	if !reflect.DeepEqual(p["template"], want) {
+		t.Errorf("template = %v", p["template"])
+	}
+	if _, ok := p["html"]; ok {
+		t.Error("html set")
+	}
+	if ve, ok := c.RenderTemplateID("", nil).validate().(*ValidationError); !ok || ve.Field != "template.id" {
+		t.Error("empty id accepted")
+	}
+}
@@ -0,0 +1,84 @@
+package forge
+
+import (
+	"html"
+	"strings"
+)
+
+// TextOptions controls how RenderText lays out plain text.
+type TextOptions struct {
+	// Title is shown above the text and used as the document title.
+	Title string
+	// Font is a CSS font-family, e.g. "Georgia, serif" or a family added
+	// with Font (default sans-serif, or monospace with Monospace).
+	Font string
+	// FontSize is the CSS font size of the text (default "10pt").
+	FontSize string
+	// Monospace sets a monospace font and keeps runs of spaces, for logs
+	// and column-aligned reports.
+	Monospace bool
+	// NoWrap keeps long lines on one line instead of wrapping them at the
+	// page edge; overflowing text is cut off.
+	NoWrap bool
+}
+
+// RenderText starts a render request for plain text, e.g. logs or
+// generated reports. Line breaks are kept and pages break as needed.
+func (c *Client) RenderText(text string, opts TextOptions) *RenderRequest {
+	r := c.RenderHTML(textHTML(text, opts))
+	if opts.Title != "" {
+		r.PdfTitle(opts.Title)
+	}
+	return r
+}
+
+func textHTML(text string, opts TextOptions) string {
+	font := opts.Font
+	if font == "" {
+		font = "sans-serif"
+		if opts.Monospace {
+			font = "monospace"
+		}
+	}
+	fontSize := opts.FontSize
+	if fontSize == "" {
+		fontSize = "10pt"
+	}
+	// pre-line keeps line breaks but collapses spaces, which suits prose.
+	space := "pre-line"
+	switch {
+	case opts.Monospace && opts.NoWrap:
+		space = "pre"
+	case opts.Monospace:
+		space = "pre-wrap"
+	case opts.NoWrap:
+		space = "nowrap"
+	}
+
+	var b strings.Builder
+	b.WriteString("<!DOCTYPE html>\n<html><head><meta charset=\"utf-8\">")
+	if opts.Title != "" {
+		b.WriteString("<title>" + html.EscapeString(opts.Title) + "</title>")
+	}
+	b.WriteString("<style>\nbody{font-family:" + cssValue(font) + ";font-size:" + cssValue(fontSize) + "}\n")
+	b.WriteString("h1{font-family:sans-serif}\n")
+	b.WriteString(".text{margin:0;font:inherit;white-space:" + space + ";overflow-wrap:anywhere;overflow:hidden}\n")
+	b.WriteString("</style></head><body>\n")
+	if opts.Title != "" {
+		b.WriteString("<h1>" + html.EscapeString(opts.Title) + "</h1>\n")
+	}
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	b.WriteString(`<div class="text">` + html.EscapeString(text) + "</div>\n</body></html>\n")
+	return b.String()
+}
+
+// cssValue strips characters that could end a CSS declaration or the
+// style element. Unlike HTML escaping, it keeps quoted font names intact.
+func cssValue(s string) string {
+	return strings.Map(func(r rune) rune {
+		if strings.ContainsRune("<>{};", r) {
+			return -1
+		}
+		return r
+	}, s)
+}
@@ -0,0 +1,40 @@
+package forge
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderText(t *testing.T) {
+	c := NewClient("http://localhost:3000")
+	p := c.RenderText("ERROR <db> timeout\r\n    at query()", TextOptions{Title: "app.log", Monospace: true, FontSize: "8pt"}).Payload()
+	h := *p.HTML
+	for _, want := range []string{
+		"font-family:monospace;font-size:8pt",
+		"white-space:pre-wrap",
+		"<h1>app.log</h1>",
+		"ERROR &lt;db&gt; timeout\n    at query()",
+	} {
+		if !strings.Contains(h, want) {
+			t.Errorf("html missing %q", want)
+		}
+	}
+	if p.Pdf == nil || *p.Pdf.Title != "app.log" {
+		t.Error("pdf title not set")
+	}
+
+	for _, tt := range []struct {
+		opts TextOptions
+		want string
+	}{
+		{TextOptions{}, "white-space:pre-line"},
+		{TextOptions{NoWrap: true}, "white-space:nowrap"},
+		{TextOptions{Monospace: true, NoWrap: true}, "white-space:pre;"},
+		{TextOptions{Font: "'Fira Code', monospace"}, "font-family:'Fira Code', monospace;"},
+		{TextOptions{Font: "x}</style><script>"}, "font-family:x/stylescript;"},
+	} {
+		if h := *c.RenderText("x", tt.opts).Payload().HTML; !strings.Contains(h, tt.want) {
+			t.Errorf("%+v: html missing %q", tt.opts, tt.want)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package forge
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// textLayoutType is the content type of the text layout part of a
+// multipart render response.
+const textLayoutType = "application/vnd.forge.text-layout+json"
+
+// TextLayout is the positioned text of a rendered document.
+type TextLayout struct {
+	Pages []TextPage `json:"pages"`
+}
+
+// TextPage is the text of one page. Sizes are in PDF points.
+type TextPage struct {
+	Number int         `json:"number"`
+	Width  float64     `json:"width"`
+	Height float64     `json:"height"`
+	Blocks []TextBlock `json:"blocks"`
+}
+
+// TextBlock is a run of text in one font, positioned from the page's
+// top-left corner.
+type TextBlock struct {
+	X        float64 `json:"x"`
+	Y        float64 `json:"y"`
+	Width    float64 `json:"width"`
+	Height   float64 `json:"height"`
+	Font     string  `json:"font"`
+	FontSize float64 `json:"font_size"`
+	Text     string  `json:"text"`
+}
+
+// IncludeTextLayout asks the server to return the positioned text of the
+// rendered document alongside the output, in RenderResponse.TextLayout.
+func (r *RenderRequest) IncludeTextLayout(enabled bool) *RenderRequest {
+	r.p.TextLayout = &enabled
+	return r
+}
+
+// TextDump returns a canonical per-page text and layout summary of the
+// response, stable enough to commit as a golden file: blocks are sorted
+// top to bottom, left to right, and positions are rounded to whole points
+// so sub-point rendering jitter does not show up as a diff. It requires a
+// request made with IncludeTextLayout(true).
+func (res *RenderResponse) TextDump() (string, error) {
+	if res.TextLayout == nil {
+		return "", fmt.Errorf("forge: no text layout in response; render with IncludeTextLayout(true)")
+	}
+	var b strings.Builder
+	for _, page := range res.TextLayout.Pages {
+		fmt.Fprintf(&b, "=== page %d (%gx%g) ===\n", page.Number, math.Round(page.Width), math.Round(page.Height))
+		blocks := append([]TextBlock(nil), page.Blocks...)
+		sort.SliceStable(blocks, func(i, j int) bool {
+			yi, yj := math.Round(blocks[i].Y), math.Round(blocks[j].Y)
+			if yi != yj {
+				return yi < yj
+			}
+			return math.Round(blocks[i].X) < math.Round(blocks[j].X)
+		})
+		for _, bl := range blocks {
+			fmt.Fprintf(&b, "[%g,%g %gx%g] %s %g: %s\n",
+				math.Round(bl.X), math.Round(bl.Y), math.Round(bl.Width), math.Round(bl.Height),
+				bl.Font, bl.FontSize, strings.Join(strings.Fields(bl.Text), " "))
+		}
+	}
+	return b.String(), nil
+}
+
+// applyParts fills res from the parts of a multipart render response: the
+// text layout part, if any, and the document itself.
+func (res *RenderResponse) applyParts(parts []responsePart) error {
+	res.Data = nil
+	for _, p := range parts {
+		if p.header.Get("Content-Type") == textLayoutType {
+			var layout TextLayout
+			if err := json.Unmarshal(p.data, &layout); err != nil {
+				return fmt.Errorf("forge: text layout: %w", err)
+			}
+			res.TextLayout = &layout
+		} else if res.Data == nil {
+			res.Data = p.data
+		}
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package forge
+
+import (
+	"context"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"testing"
+)
+
+func TestTextDump(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mw := multipart.NewWriter(w)
+		w.Header().Set("Content-Type", mw.FormDataContentType())
+		pdf, _ := mw.CreatePart(textproto.MIMEHeader{"Content-Type": {"application/pdf"}})
+		pdf.Write([]byte("%PDF"))
+		layout, _ := mw.CreatePart(textproto.MIMEHeader{"Content-Type": {textLayoutType}})
+		layout.Write([]byte(`{"pages":[{"number":1,"width":595.28,"height":841.89,"blocks":[
+			{"x":72.2,"y":120.4,"width":80,"height":12,"font":"Inter","font_size":10,"text":"Total:  42.00"},
+			{"x":72,"y":72.3,"width":200.6,"height":24,"font":"Inter-Bold","font_size":20,"text":"Invoice"}
+		]}]}`))
+		mw.Close()
+	}))
+	defer srv.Close()
+
+	res, err := NewClient(srv.URL).RenderHTML("x").IncludeTextLayout(true).SendWithWarnings(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(res.Data) != "%PDF" {
+		t.Errorf("Data = %q", res.Data)
+	}
+	dump, err := res.TextDump()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "=== page 1 (595x842) ===\n" +
+		"[72,72 201x24] Inter-Bold 20: Invoice\n" +
+		"[72,120 80x12] Inter 10: Total: 42.00\n"
+	if dump != want {
+		t.Errorf("TextDump =\n%s\nwant\n%s", dump, want)
+	}
+
+	if _, err := (&RenderResponse{}).TextDump(); err == nil {
+		t.Error("TextDump without layout succeeded")
+	}
+}
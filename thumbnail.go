@@ -0,0 +1,75 @@
+package forge
+
+// FitMode specifies how a thumbnail fills its box.
+type FitMode string
+
+const (
+	// FitContain scales the page to fit inside the box, keeping its aspect
+	// ratio. The thumbnail may be smaller than the box in one dimension.
+	FitContain FitMode = "contain"
+	// FitCover scales the page to cover the box, keeping its aspect ratio,
+	// and crops the overflow.
+	FitCover FitMode = "cover"
+	// FitFill stretches the page to the box exactly.
+	FitFill FitMode = "fill"
+)
+
+// ThumbnailOptions requests a downscaled preview of one page.
+type ThumbnailOptions struct {
+	Width  int     `json:"width"`
+	Height int     `json:"height"`
+	Fit    FitMode `json:"fit"`
+	// Page is the 1-based page to preview (default 1).
+	Page *int `json:"page,omitempty"`
+}
+
+// Thumbnail returns a downscaled preview of the first page, sized to the
+// width x height box in pixels, instead of the full-size render. Use
+// ThumbnailPage to preview another page. Image formats only.
+func (r *RenderRequest) Thumbnail(width, height int, fit FitMode) *RenderRequest {
+	if width <= 0 || height <= 0 {
+		r.fail("thumbnail", "size must be positive, got %dx%d", width, height)
+		return r
+	}
+	switch fit {
+	case FitContain, FitCover, FitFill:
+	default:
+		r.fail("thumbnail.fit", "unknown fit mode %q", fit)
+		return r
+	}
+	page := (*int)(nil)
+	if r.p.Thumbnail != nil {
+		page = r.p.Thumbnail.Page
+	}
+	r.p.Thumbnail = &ThumbnailOptions{Width: width, Height: height, Fit: fit, Page: page}
+	return r
+}
+
+// ThumbnailPage selects the 1-based page previewed by Thumbnail.
+func (r *RenderRequest) ThumbnailPage(n int) *RenderRequest {
+	if n < 1 {
+		r.fail("thumbnail.page", "must be at least 1, got %d", n)
+		return r
+	}
+	if r.p.Thumbnail == nil {
+		r.p.Thumbnail = &ThumbnailOptions{}
+	}
+	r.p.Thumbnail.Page = &n
+	return r
+}
+
+// validateThumbnail rejects thumbnails of PDF output and a ThumbnailPage
+// without a Thumbnail size.
+func (r *RenderRequest) validateThumbnail() error {
+	t := r.p.Thumbnail
+	if t == nil {
+		return nil
+	}
+	if t.Width == 0 {
+		return &ValidationError{Field: "thumbnail", Message: "ThumbnailPage requires Thumbnail"}
+	}
+	if r.p.Format == "" || r.p.Format == FormatPDF {
+		return &ValidationError{Field: "thumbnail", Message: "applies only to image formats"}
+	}
+	return nil
+}
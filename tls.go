@@ -0,0 +1,92 @@
+package forge
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// WithTLSConfig sets the TLS configuration used to connect to the server.
+// The config is cloned, so later changes by the caller have no effect.
+// WithClientCertificate and WithCACert applied after it adjust the clone.
+// It requires the HTTP client's transport to be an *http.Transport (or
+// nil); with a custom RoundTripper, every request fails with a
+// *ValidationError instead of connecting without it.
+func WithTLSConfig(cfg *tls.Config) Option {
+	return func(c *Client) {
+		c.tlsConfig = cfg.Clone()
+	}
+}
+
+// WithClientCertificate presents the given PEM certificate and key files
+// for mutual TLS. The files are loaded on the first handshake; load errors
+// surface as a *ConnectionError from the request that triggered it.
+// It requires the HTTP client's transport to be an *http.Transport (or
+// nil); with a custom RoundTripper, every request fails with a
+// *ValidationError instead of connecting without it.
+func WithClientCertificate(certFile, keyFile string) Option {
+	return func(c *Client) {
+		var (
+			once sync.Once
+			cert tls.Certificate
+			err  error
+		)
+		c.tls().GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			once.Do(func() {
+				cert, err = tls.LoadX509KeyPair(certFile, keyFile)
+			})
+			if err != nil {
+				return nil, err
+			}
+			return &cert, nil
+		}
+	}
+}
+
+// WithCACert trusts the PEM-encoded CA certificates in pem when verifying
+// the server, in place of the system roots. If pem contains no valid
+// certificates, every handshake fails verification.
+// It requires the HTTP client's transport to be an *http.Transport (or
+// nil); with a custom RoundTripper, every request fails with a
+// *ValidationError instead of connecting without it.
+func WithCACert(pem []byte) Option {
+	return func(c *Client) {
+		pool := x509.NewCertPool()
+		pool.AppendCertsFromPEM(pem)
+		c.tls().RootCAs = pool
+	}
+}
+
+// tls returns the client's pending TLS config, creating it if needed.
+func (c *Client) tls() *tls.Config {
+	if c.tlsConfig == nil {
+		c.tlsConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	return c.tlsConfig
+}
+
+// applyTLS installs the pending TLS config on a copy of the HTTP client's
+// transport, leaving any caller-supplied client and transport untouched.
+// A custom RoundTripper cannot be configured, so the client is marked
+// unusable rather than silently sending requests without the TLS options.
+func (c *Client) applyTLS() {
+	var tr *http.Transport
+	switch t := c.httpClient.Transport.(type) {
+	case nil:
+		tr = http.DefaultTransport.(*http.Transport).Clone()
+	case *http.Transport:
+		tr = t.Clone()
+	default:
+		if c.err == nil {
+			c.err = &ValidationError{Field: "tls", Message: fmt.Sprintf("TLS options cannot be applied to a %T transport; configure TLS on it directly", t)}
+		}
+		return
+	}
+	tr.TLSClientConfig = c.tlsConfig
+
+	hc := *c.httpClient
+	hc.Transport = tr
+	c.httpClient = &hc
+}
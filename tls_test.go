@@ -0,0 +1,75 @@
+package forge
+
+import (
+	"context"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWithCACert(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	ok, err := NewClient(srv.URL, WithCACert(caPEM)).Health(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Error("health = false, want true")
+	}
+}
+
+func TestWithCACertRejectsUnknownServer(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	_, err := NewClient(srv.URL, WithCACert(nil)).Health(context.Background())
+	var ce *ConnectionError
+	if !errors.As(err, &ce) {
+		t.Fatalf("err = %v, want *ConnectionError", err)
+	}
+}
+
+func TestClientCertificateLoadError(t *testing.T) {
+	c := NewClient("https://forge", WithClientCertificate("missing.crt", "missing.key"))
+	if c.tlsConfig.GetClientCertificate == nil {
+		t.Fatal("GetClientCertificate not installed")
+	}
+	if _, err := c.tlsConfig.GetClientCertificate(nil); err == nil {
+		t.Error("expected load error for missing files")
+	}
+}
+
+func TestWithTLSConfigLeavesCustomClientUntouched(t *testing.T) {
+	hc := &http.Client{}
+	c := NewClient("https://forge", WithHTTPClient(hc), WithCACert(nil))
+	if hc.Transport != nil {
+		t.Error("caller's http.Client was modified")
+	}
+	if _, ok := c.httpClient.Transport.(*http.Transport); !ok {
+		t.Errorf("transport = %T, want *http.Transport", c.httpClient.Transport)
+	}
+}
+
+type stubTransport struct{}
+
+func (stubTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, errors.New("stub transport reached")
+}
+
+func TestTLSOptionsWithCustomRoundTripper(t *testing.T) {
+	hc := &http.Client{Transport: stubTransport{}}
+	c := NewClient("https://forge", WithHTTPClient(hc), WithClientCertificate("cert.pem", "key.pem"))
+
+	if _, err := c.Health(context.Background()); !errors.As(err, new(*ValidationError)) {
+		t.Errorf("Health err = %v, want *ValidationError", err)
+	}
+	_, err := c.RenderHTML("x").Send(context.Background())
+	if ve, ok := err.(*ValidationError); !ok || ve.Field != "tls" {
+		t.Errorf("Send err = %v, want tls *ValidationError", err)
+	}
+}
@@ -0,0 +1,55 @@
+package forge
+
+import "strings"
+
+// TOCOptions describes a printed table of contents generated from the
+// document's headings.
+type TOCOptions struct {
+	// Title is the heading of the TOC page, e.g. "Contents".
+	Title string `json:"title,omitempty"`
+	// Selectors lists the elements that become entries, outermost level
+	// first. It defaults to h1 through h6.
+	Selectors []string `json:"selectors,omitempty"`
+	// MaxDepth limits the entry levels, 1-6; zero includes all levels.
+	MaxDepth int `json:"max_depth,omitempty"`
+	// PageNumbers prints each entry's page number.
+	PageNumbers bool `json:"page_numbers"`
+	// InsertAfterPage places the TOC after the given page, e.g. 1 to
+	// follow a cover page; zero puts it first.
+	InsertAfterPage int `json:"insert_after_page,omitempty"`
+}
+
+// PdfTOC inserts a printed table of contents, generated from headings, into
+// a paginated PDF. Unlike PdfBookmarks it adds pages to the document:
+//
+//	req.PdfTOC(forge.TOCOptions{Title: "Contents", MaxDepth: 2, PageNumbers: true, InsertAfterPage: 1})
+func (r *RenderRequest) PdfTOC(opts TOCOptions) *RenderRequest {
+	for _, s := range opts.Selectors {
+		if strings.TrimSpace(s) == "" {
+			r.fail("pdf.toc.selectors", "empty selector")
+			return r
+		}
+	}
+	if opts.MaxDepth < 0 || opts.MaxDepth > 6 {
+		r.fail("pdf.toc.max_depth", "must be between 0 and 6, got %d", opts.MaxDepth)
+		return r
+	}
+	if opts.InsertAfterPage < 0 {
+		r.fail("pdf.toc.insert_after_page", "must not be negative, got %d", opts.InsertAfterPage)
+		return r
+	}
+	r.pdf().TOC = &opts
+	return r
+}
+
+// validateTOC rejects a table of contents on continuous output, which has
+// no pages to list.
+func (r *RenderRequest) validateTOC() error {
+	if r.p.Pdf == nil || r.p.Pdf.TOC == nil {
+		return nil
+	}
+	if r.p.Flow != nil && *r.p.Flow == FlowContinuous {
+		return &ValidationError{Field: "pdf.toc", Message: "requires paginated output"}
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package forge
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPdfTOC(t *testing.T) {
+	c := NewClient("http://localhost")
+	r := c.RenderHTML("x").PdfTOC(TOCOptions{
+		Title:           "Contents",
+		Selectors:       []string{"h1", ".chapter-title"},
+		MaxDepth:        2,
+		InsertAfterPage: 1,
+	})
+	toc := payloadMap(t, r)["pdf"].(map[string]any)["toc"].(map[string]any)
+	want := map[string]any{
+		"title":             "Contents",
+		"selectors":         []any{"h1", ".chapter-title"},
+		"max_depth":         2.0,
+		"page_numbers":      false,
+		"insert_after_page": 1.0,
+	}
+	if !reflect.DeepEqual(toc, want) {
+		t.Errorf("toc = %v, want %v", toc, want)
+	}
+
+	tests := []struct {
+		r     *RenderRequest
+		field string
+	}{
+		{c.RenderHTML("x").PdfTOC(TOCOptions{Selectors: []string{" "}}), "pdf.toc.selectors"},
+		{c.RenderHTML("x").PdfTOC(TOCOptions{MaxDepth: 7}), "pdf.toc.max_depth"},
+		{c.RenderHTML("x").PdfTOC(TOCOptions{InsertAfterPage: -1}), "pdf.toc.insert_after_page"},
+		{c.RenderHTML("x").Flow(FlowContinuous).PdfTOC(TOCOptions{}), "pdf.toc"},
+	}
+	for _, tt := range tests {
+		err := tt.r.validate()
+		if ve, ok := err.(*ValidationError); !ok || ve.Field != tt.field {
+			t.Errorf("got %v, want %s error", err, tt.field)
+		}
+	}
+}
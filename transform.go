@@ -0,0 +1,31 @@
+package forge
+
+import (
+	"context"
+	"fmt"
+)
+
+// ResultTransform post-processes a successful render before it is
+// returned, e.g. to stamp internal IDs, virus-scan, or re-compress the
+// output. It may replace res.Data. A non-nil error fails the render.
+type ResultTransform func(ctx context.Context, res *RenderResponse) error
+
+// WithResultTransform adds a transform applied to every successful
+// response of Send and SendWithWarnings, and the calls built on them.
+// Transforms run in the order they were added. SendRaw and SendPages
+// responses are not transformed.
+func WithResultTransform(fn ResultTransform) Option {
+	return func(c *Client) {
+		c.transforms = append(c.transforms, fn)
+	}
+}
+
+// transform applies the client's result transforms to res.
+func (c *Client) transform(ctx context.Context, res *RenderResponse) error {
+	for i, fn := range c.transforms {
+		if err := fn(ctx, res); err != nil {
+			return fmt.Errorf("forge: result transform %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
@@ -1,9 +1,14 @@
 package forge
 
+import "time"
+
 // OutputFormat specifies the rendered output format.
 type OutputFormat string
 
 const (
+	// FormatAuto omits the format so the server picks one from the
+	// request's Accept header (see RenderRequest.Accept).
+	FormatAuto OutputFormat = "auto"
 	FormatPDF  OutputFormat = "pdf"
 	FormatPNG  OutputFormat = "png"
 	FormatJPEG OutputFormat = "jpeg"
@@ -11,6 +16,31 @@ const (
 	FormatTGA  OutputFormat = "tga"
 	FormatQOI  OutputFormat = "qoi"
 	FormatSVG  OutputFormat = "svg"
+	FormatWebP OutputFormat = "webp"
+	FormatTIFF OutputFormat = "tiff"
+	FormatAVIF OutputFormat = "avif"
+	// FormatGIF and FormatAPNG animate the document, one frame per page.
+	FormatGIF  OutputFormat = "gif"
+	FormatAPNG OutputFormat = "apng"
+	// FormatHTML is the output format of EmailMode.
+	FormatHTML OutputFormat = "html"
+)
+
+// Engine is a server rendering engine.
+type Engine string
+
+const (
+	EngineChromium Engine = "chromium"
+	EngineWebKit   Engine = "webkit"
+	EngineTypeset  Engine = "typeset"
+)
+
+// MediaType is the CSS media type a page is rendered with.
+type MediaType string
+
+const (
+	MediaScreen MediaType = "screen"
+	MediaPrint  MediaType = "print"
 )
 
 // Orientation specifies page orientation.
@@ -21,6 +51,38 @@ const (
 	Landscape Orientation = "landscape"
 )
 
+// Paper is a named paper size.
+type Paper string
+
+const (
+	PaperA3      Paper = "a3"
+	PaperA4      Paper = "a4"
+	PaperA5      Paper = "a5"
+	PaperB4      Paper = "b4"
+	PaperB5      Paper = "b5"
+	PaperLetter  Paper = "letter"
+	PaperLegal   Paper = "legal"
+	PaperLedger  Paper = "ledger"
+	PaperTabloid Paper = "tabloid"
+)
+
+// Unit is a length unit for page dimensions.
+type Unit string
+
+const (
+	UnitMM Unit = "mm"
+	UnitIn Unit = "in"
+	UnitPt Unit = "pt"
+	UnitPx Unit = "px"
+)
+
+// PaperDimensions is a custom paper size.
+type PaperDimensions struct {
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+	Unit   Unit    `json:"unit"`
+}
+
 // Flow specifies the document flow mode.
 type Flow string
 
@@ -28,16 +90,19 @@ const (
 	FlowAuto       Flow = "auto"
 	FlowPaginate   Flow = "paginate"
 	FlowContinuous Flow = "continuous"
+	// FlowHybrid renders continuously and breaks into pages only where the
+	// content exceeds MaxPageHeight.
+	FlowHybrid Flow = "hybrid"
 )
 
 // DitherMethod specifies the dithering algorithm.
 type DitherMethod string
 
 const (
-	DitherNone          DitherMethod = "none"
+	DitherNone           DitherMethod = "none"
 	DitherFloydSteinberg DitherMethod = "floyd-steinberg"
-	DitherAtkinson      DitherMethod = "atkinson"
-	DitherOrdered       DitherMethod = "ordered"
+	DitherAtkinson       DitherMethod = "atkinson"
+	DitherOrdered        DitherMethod = "ordered"
 )
 
 // WatermarkLayer specifies whether the watermark renders over or under content.
@@ -53,8 +118,15 @@ type PdfStandard string
 
 const (
 	PdfStandardNone PdfStandard = "none"
+	PdfStandardA1B  PdfStandard = "pdf/a-1b"
 	PdfStandardA2B  PdfStandard = "pdf/a-2b"
+	PdfStandardA2U  PdfStandard = "pdf/a-2u"
 	PdfStandardA3B  PdfStandard = "pdf/a-3b"
+	PdfStandardA3U  PdfStandard = "pdf/a-3u"
+	PdfStandardA4   PdfStandard = "pdf/a-4"
+	// PdfStandardUA1 is PDF/UA-1, for PdfClient.Validate. Produce it with
+	// PdfAccessibility(AccessibilityPdfUa1).
+	PdfStandardUA1 PdfStandard = "pdf/ua-1"
 )
 
 // EmbedRelationship represents the relationship of an embedded file to the PDF.
@@ -70,11 +142,11 @@ const (
 
 // EmbeddedFile represents a file to embed in the PDF.
 type EmbeddedFile struct {
-	Path         string
-	Data         string // base64-encoded
-	MimeType     string
-	Description  string
-	Relationship EmbedRelationship
+	Path         string            `json:"path"`
+	Data         string            `json:"data"` // base64-encoded
+	MimeType     string            `json:"mime_type,omitempty"`
+	Description  string            `json:"description,omitempty"`
+	Relationship EmbedRelationship `json:"relationship,omitempty"`
 }
 
 // BarcodeType specifies the barcode symbology.
@@ -141,12 +213,65 @@ const (
 	AccessibilityPdfUa1 AccessibilityLevel = "pdf/ua-1"
 )
 
+// PageNumberPosition specifies where page numbers are placed on the page.
+type PageNumberPosition string
+
+const (
+	PageNumberTopLeft      PageNumberPosition = "top-left"
+	PageNumberTopCenter    PageNumberPosition = "top-center"
+	PageNumberTopRight     PageNumberPosition = "top-right"
+	PageNumberBottomLeft   PageNumberPosition = "bottom-left"
+	PageNumberBottomCenter PageNumberPosition = "bottom-center"
+	PageNumberBottomRight  PageNumberPosition = "bottom-right"
+)
+
+// PageNumbering customizes page numbers. Zero fields use the server defaults.
+type PageNumbering struct {
+	// Start is the number of the first page (default 1).
+	Start int `json:"start,omitempty"`
+	// Format is a printf-style template receiving the page number and
+	// page count, e.g. "Seite %d von %d" (default "Page %d of %d").
+	Format string `json:"format,omitempty"`
+	// Position is where numbers are placed (default PageNumberBottomCenter).
+	Position PageNumberPosition `json:"position,omitempty"`
+	// FontSize is the font size in PDF points.
+	FontSize float64 `json:"font_size,omitempty"`
+	// SkipFirst omits the number on the first page, e.g. for cover pages.
+	SkipFirst bool `json:"skip_first,omitempty"`
+}
+
+// CacheStatus reports whether the server served a render from its cache.
+type CacheStatus string
+
+const (
+	CacheHit  CacheStatus = "HIT"
+	CacheMiss CacheStatus = "MISS"
+)
+
 // RenderResponse contains the rendered output and any CSS compatibility warnings.
 type RenderResponse struct {
 	// Data is the rendered output bytes (PDF, PNG, etc.).
 	Data []byte
 	// Warnings contains CSS compatibility warnings from the Forge server.
 	Warnings []string
+	// Engine is the rendering engine version (X-Forge-Engine), if reported.
+	Engine string
+	// CacheStatus is the server cache result (X-Forge-Cache), if reported.
+	CacheStatus CacheStatus
+	// WorkerID identifies the worker that rendered the request (X-Forge-Worker), if reported.
+	WorkerID string
+	// RenderDuration is the server-side render time (X-Forge-Render-Time), if reported.
+	RenderDuration time.Duration
+	// Attempts lists every HTTP attempt made, including retries.
+	Attempts []Attempt
+	// Elapsed is the total client-side time across all attempts and backoff delays.
+	Elapsed time.Duration
+	// Locale is the document language set with DocumentLocale, as confirmed
+	// by the server (Content-Language) or else as requested.
+	Locale string
+	// TextLayout is the document's positioned text, if requested with
+	// IncludeTextLayout.
+	TextLayout *TextLayout
 }
 
 // Palette specifies a built-in color palette preset.
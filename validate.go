@@ -0,0 +1,117 @@
+package forge
+
+import "fmt"
+
+// fail records a builder error, reported by the request's Send methods.
+// Only the first error is kept.
+func (r *RenderRequest) fail(field, format string, args ...any) {
+	if r.err == nil {
+		r.err = &ValidationError{Field: field, Message: fmt.Sprintf(format, args...)}
+	}
+}
+
+// validate reports the first problem with the request, if any.
+func (r *RenderRequest) validate() error {
+	if r.client.err != nil {
+		return r.client.err
+	}
+	if r.err != nil {
+		return r.err
+	}
+	for _, check := range []func() error{
+		r.validateTemplate,
+		r.validateArchive,
+		r.validateTransparent,
+		r.validateClip,
+		r.validateCapture,
+		r.validateDraft,
+		r.validateBarcodes,
+		r.validateImage,
+		r.validateEmail,
+		r.validateFlow,
+		r.validateThumbnail,
+		r.validateColor,
+		r.validatePdfStandard,
+		r.validateViewer,
+		r.validateTOC,
+		r.validateOutline,
+		r.validateForms,
+		r.validateOrientationOverrides,
+		r.validateFetch,
+		r.validateNetwork,
+		r.validateWait,
+		r.validateComplexity,
+	} {
+		if err := check(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateTransparent rejects a transparent background combined with a
+// background color or an output format without an alpha channel.
+func (r *RenderRequest) validateTransparent() error {
+	if r.p.Transparent == nil || !*r.p.Transparent {
+		return nil
+	}
+	if r.p.Background != nil {
+		return &ValidationError{Field: "transparent", Message: "conflicts with background"}
+	}
+	if r.p.Format == FormatJPEG || r.p.Format == FormatBMP {
+		return &ValidationError{Field: "transparent", Message: fmt.Sprintf("%s output has no alpha channel", r.p.Format)}
+	}
+	return nil
+}
+
+// validateForms rejects form fields on raster PDF output, which flattens
+// every page into an image.
+func (r *RenderRequest) validateForms() error {
+	pdf := r.p.Pdf
+	if pdf == nil || pdf.Forms == nil || !*pdf.Forms {
+		return nil
+	}
+	if pdf.Mode != nil && *pdf.Mode == PdfModeRaster {
+		return &ValidationError{Field: "pdf.forms", Message: "conflicts with PdfModeRaster"}
+	}
+	return nil
+}
+
+// validateClip rejects a clip region on PDF output.
+func (r *RenderRequest) validateClip() error {
+	if r.p.Clip != nil && (r.p.Format == "" || r.p.Format == FormatPDF) {
+		return &ValidationError{Field: "clip", Message: "applies only to image formats"}
+	}
+	return nil
+}
+
+// validateCapture rejects element capture on PDF output, combined with a
+// clip region, or with negative padding.
+func (r *RenderRequest) validateCapture() error {
+	c := r.p.Capture
+	if c == nil {
+		return nil
+	}
+	if r.p.Format == "" || r.p.Format == FormatPDF {
+		return &ValidationError{Field: "capture", Message: "applies only to image formats"}
+	}
+	if r.p.Clip != nil {
+		return &ValidationError{Field: "capture", Message: "conflicts with clip"}
+	}
+	if c.Padding != nil && *c.Padding < 0 {
+		return &ValidationError{Field: "capture.padding", Message: fmt.Sprintf("must not be negative, got %g", *c.Padding)}
+	}
+	return nil
+}
+
+// validateFlow ties MaxPageHeight to FlowHybrid.
+func (r *RenderRequest) validateFlow() error {
+	hybrid := r.p.Flow != nil && *r.p.Flow == FlowHybrid
+	if r.p.MaxPageHeight != nil && !hybrid {
+		return &ValidationError{Field: "max_page_height", Message: "applies only to FlowHybrid"}
+	}
+	if hybrid && r.p.MaxPageHeight == nil {
+		return &ValidationError{Field: "max_page_height", Message: "FlowHybrid requires MaxPageHeight"}
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package forge
+
+// PageLayout specifies how a PDF viewer arranges pages when the document
+// is opened.
+type PageLayout string
+
+const (
+	PageLayoutSinglePage PageLayout = "single-page"
+	PageLayoutOneColumn  PageLayout = "one-column"
+	// PageLayoutTwoColumnLeft shows two columns of pages with odd pages on
+	// the left.
+	PageLayoutTwoColumnLeft  PageLayout = "two-column-left"
+	PageLayoutTwoColumnRight PageLayout = "two-column-right"
+	// PageLayoutTwoPageLeft shows two pages at a time with odd pages on the
+	// left, like an open book.
+	PageLayoutTwoPageLeft  PageLayout = "two-page-left"
+	PageLayoutTwoPageRight PageLayout = "two-page-right"
+)
+
+// PageMode specifies which panel a PDF viewer shows when the document is
+// opened.
+type PageMode string
+
+const (
+	PageModeNone        PageMode = "none"
+	PageModeOutlines    PageMode = "outlines"
+	PageModeThumbnails  PageMode = "thumbnails"
+	PageModeAttachments PageMode = "attachments"
+	PageModeFullScreen  PageMode = "full-screen"
+)
+
+// ViewerPrefs controls how PDF viewers present the document when it is
+// opened. Zero fields leave the choice to the viewer.
+type ViewerPrefs struct {
+	PageLayout PageLayout `json:"page_layout,omitempty"`
+	// PageMode PageModeOutlines shows the bookmarks panel.
+	PageMode    PageMode `json:"page_mode,omitempty"`
+	FitWindow   bool     `json:"fit_window,omitempty"`
+	HideToolbar bool     `json:"hide_toolbar,omitempty"`
+	// InitialZoom is the zoom factor of the first page, e.g. 1.5 for 150%.
+	InitialZoom float64 `json:"initial_zoom,omitempty"`
+}
+
+// PdfViewerPrefs sets how PDF viewers present the document when it is
+// opened, e.g. two pages side by side with the bookmarks panel shown:
+//
+//	req.PdfViewerPrefs(forge.ViewerPrefs{
+//		PageLayout: forge.PageLayoutTwoPageRight,
+//		PageMode:   forge.PageModeOutlines,
+//	})
+func (r *RenderRequest) PdfViewerPrefs(prefs ViewerPrefs) *RenderRequest {
+	switch prefs.PageLayout {
+	case "", PageLayoutSinglePage, PageLayoutOneColumn, PageLayoutTwoColumnLeft,
+		PageLayoutTwoColumnRight, PageLayoutTwoPageLeft, PageLayoutTwoPageRight:
+	default:
+		r.fail("pdf.viewer_prefs.page_layout", "unknown page layout %q", prefs.PageLayout)
+		return r
+	}
+	switch prefs.PageMode {
+	case "", PageModeNone, PageModeOutlines, PageModeThumbnails, PageModeAttachments, PageModeFullScreen:
+	default:
+		r.fail("pdf.viewer_prefs.page_mode", "unknown page mode %q", prefs.PageMode)
+		return r
+	}
+	if prefs.InitialZoom < 0 || prefs.InitialZoom > 64 {
+		r.fail("pdf.viewer_prefs.initial_zoom", "must be between 0 and 64, got %g", prefs.InitialZoom)
+		return r
+	}
+	r.pdf().ViewerPrefs = &prefs
+	return r
+}
+
+// OpenAction is the page and zoom a PDF viewer shows when the document is
+// opened.
+type OpenAction struct {
+	// Page is the 1-based page number.
+	Page int `json:"page"`
+	// Zoom is the zoom factor, e.g. 1 for 100%; zero keeps the viewer's.
+	Zoom float64 `json:"zoom,omitempty"`
+}
+
+// PdfOpenAt makes PDF viewers open the document at the given 1-based page,
+// e.g. a summary deep in the document, with the given zoom factor (1 for
+// 100%, zero for the viewer's default).
+func (r *RenderRequest) PdfOpenAt(page int, zoom float64) *RenderRequest {
+	if page < 1 {
+		r.fail("pdf.open_action.page", "must be at least 1, got %d", page)
+		return r
+	}
+	if zoom < 0 || zoom > 64 {
+		r.fail("pdf.open_action.zoom", "must be between 0 and 64, got %g", zoom)
+		return r
+	}
+	r.pdf().OpenAction = &OpenAction{Page: page, Zoom: zoom}
+	return r
+}
+
+// validateViewer rejects an open action zoom alongside ViewerPrefs'
+// InitialZoom, which sets the same thing.
+func (r *RenderRequest) validateViewer() error {
+	pdf := r.p.Pdf
+	if pdf == nil || pdf.OpenAction == nil || pdf.ViewerPrefs == nil {
+		return nil
+	}
+	if pdf.OpenAction.Zoom != 0 && pdf.ViewerPrefs.InitialZoom != 0 {
+		return &ValidationError{Field: "pdf.open_action.zoom", Message: "conflicts with ViewerPrefs.InitialZoom"}
+	}
+	return nil
+}
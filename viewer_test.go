@@ -0,0 +1,69 @@
+package forge
+
+import "testing"
+
+func TestPdfViewerPrefs(t *testing.T) {
+	c := NewClient("http://localhost")
+	r := c.RenderHTML("x").PdfViewerPrefs(ViewerPrefs{
+		PageLayout:  PageLayoutTwoPageRight,
+		PageMode:    PageModeOutlines,
+		FitWindow:   true,
+		InitialZoom: 1.25,
+	})
+	prefs := payloadMap(t, r)["pdf"].(map[string]any)["viewer_prefs"].(map[string]any)
+	want := map[string]any{
+		"page_layout":  "two-page-right",
+		"page_mode":    "outlines",
+		"fit_window":   true,
+		"initial_zoom": 1.25,
+	}
+	if len(prefs) != len(want) {
+		t.Errorf("viewer_prefs = %v, want %v", prefs, want)
+	}
+	for k, v := range want {
+		if prefs[k] != v {
+			t.Errorf("viewer_prefs.%s = %v, want %v", k, prefs[k], v)
+		}
+	}
+
+	tests := []struct {
+		prefs ViewerPrefs
+		field string
+	}{
+		{ViewerPrefs{PageLayout: "spread"}, "pdf.viewer_prefs.page_layout"},
+		{ViewerPrefs{PageMode: "bookmarks"}, "pdf.viewer_prefs.page_mode"},
+		{ViewerPrefs{InitialZoom: -1}, "pdf.viewer_prefs.initial_zoom"},
+	}
+	for _, tt := range tests {
+		err := c.RenderHTML("x").PdfViewerPrefs(tt.prefs).validate()
+		if ve, ok := err.(*ValidationError); !ok || ve.Field != tt.field {
+			t.Errorf("%+v: got %v, want %s error", tt.prefs, err, tt.field)
+		}
+	}
+}
+
+func TestPdfOpenAt(t *testing.T) {
+	c := NewClient("http://localhost")
+	oa := payloadMap(t, c.RenderHTML("x").PdfOpenAt(12, 1.5))["pdf"].(map[string]any)["open_action"].(map[string]any)
+	if oa["page"] != 12.0 || oa["zoom"] != 1.5 {
+		t.Errorf("open_action = %v", oa)
+	}
+
+	tests := []struct {
+		r     *RenderRequest
+		field string
+	}{
+		{c.RenderHTML("x").PdfOpenAt(0, 1), "pdf.open_action.page"},
+		{c.RenderHTML("x").PdfOpenAt(3, -1), "pdf.open_action.zoom"},
+		{c.RenderHTML("x").PdfOpenAt(3, 2).PdfViewerPrefs(ViewerPrefs{InitialZoom: 1}), "pdf.open_action.zoom"},
+	}
+	for _, tt := range tests {
+		err := tt.r.validate()
+		if ve, ok := err.(*ValidationError); !ok || ve.Field != tt.field {
+			t.Errorf("got %v, want %s error", err, tt.field)
+		}
+	}
+	if err := c.RenderHTML("x").PdfOpenAt(3, 0).PdfViewerPrefs(ViewerPrefs{InitialZoom: 1}).validate(); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+}
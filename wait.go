@@ -0,0 +1,85 @@
+package forge
+
+import (
+	"strings"
+	"time"
+)
+
+// WaitOptions delays capture until the page is ready. The server applies
+// every condition that is set, in order: selector, expression, delay.
+type WaitOptions struct {
+	Selector   string `json:"selector,omitempty"`
+	Expression string `json:"expression,omitempty"`
+	// Delay is a fixed pause in milliseconds.
+	Delay int `json:"delay,omitempty"`
+}
+
+func (r *RenderRequest) wait() *WaitOptions {
+	if r.p.Wait == nil {
+		r.p.Wait = &WaitOptions{}
+	}
+	return r.p.Wait
+}
+
+// WaitForSelector delays capture until an element matches the CSS
+// selector, e.g. a marker set by a chart library once it has drawn.
+// The page load timeout still applies.
+func (r *RenderRequest) WaitForSelector(selector string) *RenderRequest {
+	if strings.TrimSpace(selector) == "" {
+		r.fail("wait.selector", "must not be empty")
+		return r
+	}
+	r.wait().Selector = selector
+	return r
+}
+
+// WaitForExpression delays capture until the JavaScript expression
+// evaluates to a truthy value, e.g. "window.chartsDone === true".
+// It requires JavaScript.
+func (r *RenderRequest) WaitForExpression(js string) *RenderRequest {
+	if strings.TrimSpace(js) == "" {
+		r.fail("wait.expression", "must not be empty")
+		return r
+	}
+	r.wait().Expression = js
+	return r
+}
+
+// WaitDelay pauses for d before capture, after any selector or expression
+// wait. It is rounded up to whole milliseconds. Prefer WaitForSelector or
+// WaitForExpression where the page can signal readiness.
+func (r *RenderRequest) WaitDelay(d time.Duration) *RenderRequest {
+	if d <= 0 {
+		r.fail("wait.delay", "must be positive, got %v", d)
+		return r
+	}
+	r.wait().Delay = int((d + time.Millisecond - 1) / time.Millisecond)
+	return r
+}
+
+// EvaluateBefore runs script in the page after it loads and before any
+// wait conditions and capture, e.g. to expand accordions or dismiss cookie
+// banners. Scripts run in the order added. It requires JavaScript.
+func (r *RenderRequest) EvaluateBefore(script string) *RenderRequest {
+	if strings.TrimSpace(script) == "" {
+		r.fail("scripts", "must not be empty")
+		return r
+	}
+	r.p.Scripts = append(r.p.Scripts, script)
+	return r
+}
+
+// validateWait rejects scripts and expression waits on pages without
+// JavaScript.
+func (r *RenderRequest) validateWait() error {
+	if r.p.JavaScript == nil || *r.p.JavaScript {
+		return nil
+	}
+	if len(r.p.Scripts) > 0 {
+		return &ValidationError{Field: "scripts", Message: "require JavaScript"}
+	}
+	if r.p.Wait != nil && r.p.Wait.Expression != "" {
+		return &ValidationError{Field: "wait.expression", Message: "requires JavaScript"}
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package forge
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+)
+
+// PdfXmp merges custom metadata into the output PDF's XMP packet. xml is
+// one or more rdf:Description elements declaring their own namespaces:
+//
+//	req.PdfXmp(`<rdf:Description rdf:about=""
+//		xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+//		xmlns:arc="https://archive.example.com/ns/1.0/">
+//		<arc:RecordID>R-2024-0117</arc:RecordID>
+//	</rdf:Description>`)
+//
+// Calling PdfXmp again appends to the earlier XML.
+func (r *RenderRequest) PdfXmp(xmlData string) *RenderRequest {
+	if err := checkXML(xmlData); err != nil {
+		r.fail("pdf.xmp", "%v", err)
+		return r
+	}
+	pdf := r.pdf()
+	if pdf.Xmp != nil {
+		xmlData = *pdf.Xmp + xmlData
+	}
+	pdf.Xmp = &xmlData
+	return r
+}
+
+// PdfXmpProperties writes simple properties into the output PDF's XMP
+// packet, keyed by qualified name, e.g. "dc:source" or "arc:CaseNumber".
+// The dc, xmp, pdf, and pdfx prefixes use their standard namespaces; any
+// other prefix gets a namespace of its own. Use PdfXmp to choose namespace
+// URIs. Calling PdfXmpProperties again adds to the earlier properties.
+func (r *RenderRequest) PdfXmpProperties(props map[string]string) *RenderRequest {
+	for name := range props {
+		if !validXmpName(name) {
+			r.fail("pdf.xmp_properties", "invalid property name %q; want prefix:Name", name)
+			return r
+		}
+	}
+	pdf := r.pdf()
+	if pdf.XmpProperties == nil {
+		pdf.XmpProperties = make(map[string]string, len(props))
+	}
+	for name, v := range props {
+		pdf.XmpProperties[name] = v
+	}
+	return r
+}
+
+// checkXML reports whether s is a well-formed sequence of XML elements.
+func checkXML(s string) error {
+	if strings.TrimSpace(s) == "" {
+		return errors.New("empty XMP")
+	}
+	d := xml.NewDecoder(strings.NewReader(s))
+	depth := 0
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			depth++
+		case xml.EndElement:
+			depth--
+		case xml.CharData:
+			if depth == 0 && strings.TrimSpace(string(tok)) != "" {
+				return errors.New("text outside an element")
+			}
+		}
+	}
+}
+
+// validXmpName reports whether name is a qualified XML name with a prefix,
+// such as "dc:source".
+func validXmpName(name string) bool {
+	prefix, local, ok := strings.Cut(name, ":")
+	return ok && validNCName(prefix) && validNCName(local)
+}
+
+func validNCName(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, c := range s {
+		letter := c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_'
+		if !letter && (i == 0 || !(c >= '0' && c <= '9' || c == '-' || c == '.')) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,37 @@
+package forge
+
+import "testing"
+
+func TestPdfXmp(t *testing.T) {
+	c := NewClient("http://localhost")
+	desc := `<rdf:Description rdf:about="" xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns:arc="https://archive.example.com/ns/1.0/"><arc:RecordID>R-1</arc:RecordID></rdf:Description>`
+	r := c.RenderHTML("x").
+		PdfXmp(desc).
+		PdfXmpProperties(map[string]string{"arc:CaseNumber": "C-42"}).
+		PdfXmpProperties(map[string]string{"dc:source": "crm"})
+	pdf := payloadMap(t, r)["pdf"].(map[string]any)
+	if pdf["xmp"] != desc {
+		t.Errorf("xmp = %v", pdf["xmp"])
+	}
+	props := pdf["xmp_properties"].(map[string]any)
+	if props["arc:CaseNumber"] != "C-42" || props["dc:source"] != "crm" {
+		t.Errorf("xmp_properties = %v", props)
+	}
+
+	tests := []struct {
+		r     *RenderRequest
+		field string
+	}{
+		{c.RenderHTML("x").PdfXmp("<rdf:Description>"), "pdf.xmp"},
+		{c.RenderHTML("x").PdfXmp("case 42"), "pdf.xmp"},
+		{c.RenderHTML("x").PdfXmp(" "), "pdf.xmp"},
+		{c.RenderHTML("x").PdfXmpProperties(map[string]string{"CaseNumber": "1"}), "pdf.xmp_properties"},
+		{c.RenderHTML("x").PdfXmpProperties(map[string]string{"arc:1st": "1"}), "pdf.xmp_properties"},
+	}
+	for _, tt := range tests {
+		err := tt.r.validate()
+		if ve, ok := err.(*ValidationError); !ok || ve.Field != tt.field {
+			t.Errorf("got %v, want %s error", err, tt.field)
+		}
+	}
+}